@@ -0,0 +1,101 @@
+package dropbox
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Polling intervals for asynchronous jobs.
+const (
+	minPollInterval = 500 * time.Millisecond
+	maxPollInterval = 5 * time.Second
+)
+
+// DefaultJobTimeout is how long asynchronous jobs are waited for, unless
+// Config.JobTimeout is set.
+const DefaultJobTimeout = 10 * time.Minute
+
+// ErrJobTimeout is returned when an asynchronous job does not complete in time.
+var ErrJobTimeout = errors.New("dropbox: timed out waiting for job")
+
+// poll calls check until it reports the job is done or fails, backing
+// off between attempts, or returns ErrJobTimeout after timeout.
+func poll(timeout time.Duration, check func() (done bool, err error)) error {
+	if timeout <= 0 {
+		timeout = DefaultJobTimeout
+	}
+
+	deadline := time.Now().Add(timeout)
+	interval := minPollInterval
+
+	for {
+		done, err := check()
+		if err != nil || done {
+			return err
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return ErrJobTimeout
+		}
+
+		time.Sleep(interval)
+
+		if interval *= 2; interval > maxPollInterval {
+			interval = maxPollInterval
+		}
+	}
+}
+
+// jobStatus is implemented by the status outputs of asynchronous jobs.
+type jobStatus interface {
+	jobStatus() (tag string, failed *Error)
+}
+
+// waitForJob polls check until the job completes or fails, returning
+// the completed status, or ErrJobTimeout after Config.JobTimeout.
+func (c *Client) waitForJob(check func() (jobStatus, error)) (status jobStatus, err error) {
+	err = poll(c.JobTimeout, func() (bool, error) {
+		s, err := check()
+		if err != nil {
+			return false, err
+		}
+
+		tag, failed := s.jobStatus()
+
+		switch {
+		case failed != nil:
+			return false, failed
+		case tag == "complete":
+			status = s
+			return true, nil
+		}
+
+		return false, nil
+	})
+
+	return
+}
+
+// asyncResult is the common part of the results of asynchronous jobs.
+type asyncResult struct {
+	Tag        string          `json:".tag"`
+	AsyncJobID string          `json:"async_job_id"`
+	Failed     json.RawMessage `json:"failed"`
+}
+
+// decodeAsync decodes the tag and job ID of an asynchronous result,
+// returning an Error when the job failed.
+func decodeAsync(b []byte) (tag, jobID string, failed *Error, err error) {
+	var r asyncResult
+	if err = json.Unmarshal(b, &r); err != nil {
+		return
+	}
+
+	if r.Tag == "failed" {
+		failed = unionError(r.Failed)
+		failed.Summary = "failed/" + failed.Summary
+	}
+
+	return r.Tag, r.AsyncJobID, failed, nil
+}
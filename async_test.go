@@ -0,0 +1,19 @@
+package dropbox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoll_timeout(t *testing.T) {
+	var n int
+	err := poll(time.Millisecond, func() (bool, error) {
+		n++
+		return false, nil
+	})
+
+	assert.Equal(t, ErrJobTimeout, err)
+	assert.Equal(t, 1, n)
+}
@@ -7,15 +7,18 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 )
 
 // Client implements a Dropbox client. You may use the Files and Users
 // clients directly if preferred, however Client exposes them both.
 type Client struct {
 	*Config
-	Users   *Users
-	Files   *Files
-	Sharing *Sharing
+	Users          *Users
+	Files          *Files
+	FileProperties *FileProperties
+	FileRequests   *FileRequests
+	Sharing        *Sharing
 }
 
 // New client.
@@ -23,14 +26,24 @@ func New(config *Config) *Client {
 	c := &Client{Config: config}
 	c.Users = &Users{c}
 	c.Files = &Files{c}
+	c.FileProperties = &FileProperties{c}
+	c.FileRequests = &FileRequests{c}
 	c.Sharing = &Sharing{c}
 	return c
 }
 
 // call rpc style endpoint.
 func (c *Client) call(path string, in interface{}) (io.ReadCloser, error) {
-	url := "https://api.dropboxapi.com/2" + path
+	return c.rpc("https://api.dropboxapi.com/2"+path, in)
+}
+
+// call rpc style endpoint served from the content host.
+func (c *Client) callContent(path string, in interface{}) (io.ReadCloser, error) {
+	return c.rpc("https://content.dropboxapi.com/2"+path, in)
+}
 
+// rpc performs a request with a JSON body.
+func (c *Client) rpc(url string, in interface{}) (io.ReadCloser, error) {
 	body, err := json.Marshal(in)
 	if err != nil {
 		return nil, err
@@ -49,6 +62,11 @@ func (c *Client) call(path string, in interface{}) (io.ReadCloser, error) {
 
 // download style endpoint.
 func (c *Client) download(path string, in interface{}, r io.Reader) (io.ReadCloser, int64, error) {
+	return c.downloadResult(path, in, r, nil)
+}
+
+// download style endpoint, decoding the Dropbox-API-Result header into out.
+func (c *Client) downloadResult(path string, in interface{}, r io.Reader, out interface{}) (io.ReadCloser, int64, error) {
 	url := "https://content.dropboxapi.com/2" + path
 
 	body, err := json.Marshal(in)
@@ -67,18 +85,40 @@ func (c *Client) download(path string, in interface{}, r io.Reader) (io.ReadClos
 		req.Header.Set("Content-Type", "application/octet-stream")
 	}
 
-	return c.do(req)
+	res, err := c.send(req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if result := res.Header.Get("Dropbox-API-Result"); out != nil && result != "" {
+		if err := json.Unmarshal([]byte(result), out); err != nil {
+			res.Body.Close()
+			return nil, 0, err
+		}
+	}
+
+	return res.Body, res.ContentLength, nil
 }
 
 // perform the request.
 func (c *Client) do(req *http.Request) (io.ReadCloser, int64, error) {
-	res, err := c.HTTPClient.Do(req)
+	res, err := c.send(req)
 	if err != nil {
 		return nil, 0, err
 	}
 
+	return res.Body, res.ContentLength, nil
+}
+
+// send the request, returning the response or an Error.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
 	if res.StatusCode < 400 {
-		return res.Body, res.ContentLength, err
+		return res, err
 	}
 
 	defer res.Body.Close()
@@ -93,14 +133,19 @@ func (c *Client) do(req *http.Request) (io.ReadCloser, int64, error) {
 	if strings.Contains(kind, "text/plain") {
 		if b, err := ioutil.ReadAll(res.Body); err == nil {
 			e.Summary = string(b)
-			return nil, 0, e
+			return nil, e
 		}
-		return nil, 0, err
+		return nil, err
 	}
 
 	if err := json.NewDecoder(res.Body).Decode(e); err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
-	return nil, 0, e
+	return nil, e
+}
+
+// timestamp formats t as expected by the API.
+func timestamp(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
 }
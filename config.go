@@ -2,12 +2,26 @@ package dropbox
 
 import (
 	"net/http"
+	"time"
 )
 
 // Config for the Dropbox clients.
 type Config struct {
 	HTTPClient  *http.Client
 	AccessToken string
+
+	// VerifyContentHash compares the content hash of uploaded and
+	// downloaded data with the content_hash reported by Dropbox.
+	VerifyContentHash bool
+
+	// ExportFallback exports files which cannot be downloaded, such as
+	// Paper docs, when using Files.Download.
+	ExportFallback bool
+
+	// JobTimeout limits how long methods such as SaveURLAndWait wait for
+	// asynchronous jobs before returning ErrJobTimeout, defaulting to
+	// DefaultJobTimeout.
+	JobTimeout time.Duration
 }
 
 // NewConfig with the given access token.
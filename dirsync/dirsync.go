@@ -0,0 +1,385 @@
+// Package dirsync synchronizes local directories with Dropbox folders.
+//
+// Each kind of sync first builds a Plan describing the changes it would
+// make, which may be inspected before it is applied.
+package dirsync
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tj/go-dropbox"
+)
+
+// DefaultConcurrency is the number of transfers performed in parallel.
+const DefaultConcurrency = 4
+
+// Op is the kind of change made by an Action.
+type Op string
+
+// Supported operations.
+const (
+	Upload       Op = "upload"
+	Download     Op = "download"
+	DeleteRemote Op = "delete remote"
+	DeleteLocal  Op = "delete local"
+	MakeDir      Op = "mkdir"
+)
+
+// Action is a single change to a file or folder.
+type Action struct {
+	Op   Op
+	Path string // slash separated path relative to the synced directories
+	Size int64
+
+	rev  string            // remote revision expected to be replaced
+	to   string            // new path of a conflicting local copy
+	meta *dropbox.Metadata // remote file to download
+}
+
+// String implementation.
+func (a *Action) String() string {
+	return fmt.Sprintf("%s %s", a.Op, a.Path)
+}
+
+// Plan is the set of changes a sync will make.
+type Plan struct {
+	Actions []*Action
+
+	cursor  string                // cursor to persist once applied
+	files   []*dropbox.Metadata   // remote files to record as synced
+	deleted []string              // remote paths to forget
+	synced  map[string]*FileState // file states before any changes are applied
+}
+
+// Empty returns true when there is nothing to do.
+func (p *Plan) Empty() bool {
+	return len(p.Actions) == 0
+}
+
+// Filter returns the actions of the given operation.
+func (p *Plan) Filter(op Op) (actions []*Action) {
+	for _, a := range p.Actions {
+		if a.Op == op {
+			actions = append(actions, a)
+		}
+	}
+	return
+}
+
+// String implementation.
+func (p *Plan) String() string {
+	var lines []string
+	for _, a := range p.Actions {
+		lines = append(lines, a.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// localFile is a file or directory found in the local tree.
+type localFile struct {
+	Path    string // slash separated relative path
+	Abs     string
+	Dir     bool
+	Size    int64
+	ModTime time.Time
+}
+
+// walkLocal returns the files and directories beneath root keyed by
+// their lower-cased relative path, as Dropbox paths are case-insensitive.
+// The root must be an existing directory, treating a missing one as empty
+// would delete everything on the remote side.
+func walkLocal(root string) (map[string]*localFile, error) {
+	files := make(map[string]*localFile)
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return nil, fmt.Errorf("dirsync: %s is not a directory", root)
+	}
+
+	err = filepath.Walk(root, func(abs string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, abs)
+		if err != nil {
+			return err
+		}
+
+		if rel == "." || rel == StateFilename {
+			return nil
+		}
+
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return nil
+		}
+
+		rel = filepath.ToSlash(rel)
+		files[strings.ToLower(rel)] = &localFile{
+			Path:    rel,
+			Abs:     abs,
+			Dir:     info.IsDir(),
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		}
+
+		return nil
+	})
+
+	return files, err
+}
+
+// listRemote returns the files and folders beneath root keyed by their
+// lower-cased relative path. A missing root returns a nil map.
+func listRemote(files *dropbox.Files, root string) (map[string]*dropbox.Metadata, error) {
+	entries := make(map[string]*dropbox.Metadata)
+
+	out, err := files.ListFolder(&dropbox.ListFolderInput{
+		Path:      root,
+		Recursive: true,
+	})
+
+	if isNotFound(err) {
+		return nil, nil
+	}
+
+	for {
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range out.Entries {
+			if rel, ok := relPath(root, m); ok {
+				entries[rel] = m
+			}
+		}
+
+		if !out.HasMore {
+			return entries, nil
+		}
+
+		out, err = files.ListFolderContinue(&dropbox.ListFolderContinueInput{
+			Cursor: out.Cursor,
+		})
+	}
+}
+
+// relPath returns the lower-cased path of m relative to the remote root.
+func relPath(root string, m *dropbox.Metadata) (string, bool) {
+	prefix := strings.ToLower(strings.TrimSuffix(root, "/")) + "/"
+
+	if !strings.HasPrefix(m.PathLower, prefix) || len(m.PathLower) == len(prefix) {
+		return "", false
+	}
+
+	return m.PathLower[len(prefix):], true
+}
+
+// displayPath returns the relative path rel of m in its display case where possible.
+func displayPath(rel string, m *dropbox.Metadata) string {
+	d := m.PathDisplay
+	if len(d) != len(m.PathLower) || len(d) < len(rel) {
+		return rel
+	}
+	return d[len(d)-len(rel):]
+}
+
+// names resolves the local names of remote paths. Dropbox only guarantees
+// the case of the last component of a display path, so parents take the
+// name of a folder seen earlier, or of an existing local directory.
+type names struct {
+	root  string
+	paths map[string]string
+}
+
+// newNames returns a resolver for the local directory root.
+func newNames(root string) *names {
+	return &names{root: root, paths: make(map[string]string)}
+}
+
+// resolve returns the local relative path for the lower-cased rel of m.
+func (n *names) resolve(rel string, m *dropbox.Metadata) string {
+	name := rel[strings.LastIndex(rel, "/")+1:]
+	if i := strings.LastIndex(m.PathDisplay, "/"); i != -1 && strings.EqualFold(m.PathDisplay[i+1:], name) {
+		name = m.PathDisplay[i+1:]
+	}
+
+	p := name
+	if i := strings.LastIndex(rel, "/"); i != -1 {
+		p = n.dir(rel[:i]) + "/" + name
+	}
+
+	if m.Tag == "folder" {
+		n.paths[rel] = p
+	}
+
+	return p
+}
+
+// dir returns the local relative path of the lower-cased directory rel.
+func (n *names) dir(rel string) string {
+	if p, ok := n.paths[rel]; ok {
+		return p
+	}
+
+	name := rel[strings.LastIndex(rel, "/")+1:]
+	parent := ""
+	if i := strings.LastIndex(rel, "/"); i != -1 {
+		parent = n.dir(rel[:i])
+		name = rel[i+1:]
+	}
+
+	if infos, err := ioutil.ReadDir(localPath(n.root, parent)); err == nil {
+		for _, info := range infos {
+			if info.IsDir() && strings.EqualFold(info.Name(), name) {
+				name = info.Name()
+				break
+			}
+		}
+	}
+
+	p := name
+	if parent != "" {
+		p = parent + "/" + name
+	}
+
+	n.paths[rel] = p
+	return p
+}
+
+// remotePath returns the Dropbox path of rel beneath root.
+func remotePath(root, rel string) string {
+	return path.Join("/", root, rel)
+}
+
+// localPath returns the filename of rel beneath root.
+func localPath(root, rel string) string {
+	return filepath.Join(root, filepath.FromSlash(rel))
+}
+
+// uploadFile uploads the local file to path, returning its metadata.
+func uploadFile(files *dropbox.Files, local, path string, mode dropbox.WriteMode) (*dropbox.Metadata, error) {
+	f, err := os.Open(local)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	w := files.NewWriter(path, &dropbox.WriterOptions{
+		Mode:           mode,
+		Mute:           true,
+		ClientModified: info.ModTime().UTC().Format("2006-01-02T15:04:05Z"),
+	})
+
+	if _, err := io.Copy(w, f); err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return w.Metadata, nil
+}
+
+// isNotFound returns true if err is a Dropbox path/not_found error.
+func isNotFound(err error) bool {
+	e, ok := err.(*dropbox.Error)
+	return ok && strings.Contains(e.Summary, "not_found")
+}
+
+// isUnder returns true if rel is beneath one of the given lower-cased paths.
+func isUnder(rel string, parents map[string]bool) bool {
+	for p := strings.ToLower(rel); ; {
+		i := strings.LastIndex(p, "/")
+		if i == -1 {
+			return false
+		}
+		p = p[:i]
+		if parents[p] {
+			return true
+		}
+	}
+}
+
+// localKeys returns the keys of m in order, so parents precede children.
+func localKeys(m map[string]*localFile) (keys []string) {
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return
+}
+
+// remoteKeys returns the keys of m in order, so parents precede children.
+func remoteKeys(m map[string]*dropbox.Metadata) (keys []string) {
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return
+}
+
+// each calls fn for every action using n goroutines, returning the first error.
+func each(actions []*Action, n int, fn func(*Action) error) error {
+	if n <= 0 {
+		n = DefaultConcurrency
+	}
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		first error
+	)
+
+	ch := make(chan *Action)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for a := range ch {
+				if err := fn(a); err != nil {
+					mu.Lock()
+					if first == nil {
+						first = fmt.Errorf("%s: %s", a, err)
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, a := range actions {
+		mu.Lock()
+		failed := first != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+		ch <- a
+	}
+
+	close(ch)
+	wg.Wait()
+	return first
+}
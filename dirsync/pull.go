@@ -0,0 +1,312 @@
+package dirsync
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tj/go-dropbox"
+)
+
+// Pull mirrors a Dropbox folder to a local directory. The first run lists
+// the whole folder, after which the cursor is persisted to the state file
+// and only the changes since the previous run are applied.
+type Pull struct {
+	Files  *dropbox.Files
+	Remote string // Dropbox folder, "" or "/" for the root
+	Local  string // local directory
+
+	// StateFile holding the cursor and file revisions, defaulting to
+	// StateFilename within the local directory.
+	// It cannot be shared with a Sync.
+	StateFile string
+
+	// DryRun plans changes without applying them.
+	DryRun bool
+
+	// Concurrency is the number of parallel downloads, defaulting to DefaultConcurrency.
+	Concurrency int
+}
+
+// Run plans and applies the changes, returning the plan.
+func (p *Pull) Run() (*Plan, error) {
+	plan, err := p.Plan()
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, p.Apply(plan)
+}
+
+// Plan lists the remote changes since the last run and returns the
+// local changes required.
+func (p *Pull) Plan() (*Plan, error) {
+	state, err := loadState(p.stateFile(), modePull)
+	if err != nil {
+		return nil, err
+	}
+
+	incremental := state.Cursor != ""
+
+	var out *dropbox.ListFolderOutput
+	if incremental {
+		out, err = p.Files.ListFolderContinue(&dropbox.ListFolderContinueInput{
+			Cursor: state.Cursor,
+		})
+
+		if isReset(err) {
+			incremental = false
+		}
+	}
+
+	if !incremental {
+		out, err = p.Files.ListFolder(&dropbox.ListFolderInput{
+			Path:           p.Remote,
+			Recursive:      true,
+			IncludeDeleted: true,
+		})
+	}
+
+	changes := newChanges()
+
+	for {
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range out.Entries {
+			if rel, ok := relPath(p.Remote, m); ok {
+				changes.add(rel, m)
+			}
+		}
+
+		if !out.HasMore {
+			break
+		}
+
+		out, err = p.Files.ListFolderContinue(&dropbox.ListFolderContinueInput{
+			Cursor: out.Cursor,
+		})
+	}
+
+	plan := &Plan{cursor: out.Cursor}
+	names := newNames(p.Local)
+
+	for _, k := range changes.keys {
+		m := changes.entries[k]
+		rel := names.resolve(k, m)
+		local := localPath(p.Local, rel)
+		info, err := os.Stat(local)
+		exists := err == nil
+
+		// a path deleted and then re-created is removed first so
+		// nothing stale remains beneath it
+		if incremental && exists && m.Tag != "deleted" && changes.deleted[k] {
+			plan.Actions = append(plan.Actions, &Action{Op: DeleteLocal, Path: rel})
+			plan.deleted = append(plan.deleted, k)
+			exists = false
+		}
+
+		switch m.Tag {
+		case "deleted":
+			// the initial listing includes files deleted long ago, which
+			// are only removed if they were previously synced
+			if !incremental && state.Files[k] == nil {
+				continue
+			}
+
+			if exists {
+				plan.Actions = append(plan.Actions, &Action{Op: DeleteLocal, Path: rel})
+			}
+			plan.deleted = append(plan.deleted, k)
+		case "folder":
+			if !exists || !info.IsDir() {
+				plan.Actions = append(plan.Actions, &Action{Op: MakeDir, Path: rel})
+			}
+		case "file":
+			plan.files = append(plan.files, m)
+
+			if exists && info.Mode().IsRegular() && uint64(info.Size()) == m.Size {
+				if s := state.Files[k]; s != nil && s.Rev == m.Rev {
+					continue
+				}
+
+				if h, err := dropbox.FileContentHash(local); err == nil && h == m.ContentHash {
+					continue
+				}
+			}
+
+			plan.Actions = append(plan.Actions, &Action{Op: Download, Path: rel, Size: int64(m.Size)})
+		}
+	}
+
+	return plan, nil
+}
+
+// Apply the plan, removing local files and creating directories before
+// downloading, then persists the new cursor. Nothing is changed when
+// DryRun is set.
+func (p *Pull) Apply(plan *Plan) error {
+	if p.DryRun {
+		return nil
+	}
+
+	for _, a := range plan.Filter(DeleteLocal) {
+		if err := os.RemoveAll(localPath(p.Local, a.Path)); err != nil {
+			return err
+		}
+	}
+
+	for _, a := range plan.Filter(MakeDir) {
+		if err := makeDir(localPath(p.Local, a.Path)); err != nil {
+			return err
+		}
+	}
+
+	if err := each(plan.Filter(Download), p.Concurrency, p.download); err != nil {
+		return err
+	}
+
+	state, err := loadState(p.stateFile(), modePull)
+	if err != nil {
+		return err
+	}
+
+	for _, k := range plan.deleted {
+		state.Remove(k)
+	}
+
+	for _, m := range plan.files {
+		rel, _ := relPath(p.Remote, m)
+		state.Files[rel] = &FileState{
+			Rev:         m.Rev,
+			ContentHash: m.ContentHash,
+		}
+	}
+
+	// the local directory does not exist yet when the remote folder is empty
+	if err := os.MkdirAll(filepath.Dir(p.stateFile()), 0755); err != nil {
+		return err
+	}
+
+	state.Cursor = plan.cursor
+	return state.Save(p.stateFile())
+}
+
+// download a remote file.
+func (p *Pull) download(a *Action) error {
+	out, err := p.Files.Download(&dropbox.DownloadInput{
+		Path: remotePath(p.Remote, a.Path),
+	})
+	if err != nil {
+		return err
+	}
+	defer out.Body.Close()
+
+	return writeFile(localPath(p.Local, a.Path), out.Body)
+}
+
+// stateFile returns the path of the state file.
+func (p *Pull) stateFile() string {
+	if p.StateFile != "" {
+		return p.StateFile
+	}
+	return filepath.Join(p.Local, StateFilename)
+}
+
+// changes is an ordered set of remote changes reduced to the latest
+// entry for each path.
+type changes struct {
+	keys    []string
+	entries map[string]*dropbox.Metadata
+	deleted map[string]bool // paths deleted at some point
+}
+
+// newChanges returns an empty set of changes.
+func newChanges() *changes {
+	return &changes{
+		entries: make(map[string]*dropbox.Metadata),
+		deleted: make(map[string]bool),
+	}
+}
+
+// add an entry, replacing earlier entries for the same path. Deleting a
+// path discards the earlier changes beneath it.
+func (c *changes) add(rel string, m *dropbox.Metadata) {
+	if m.Tag == "deleted" {
+		c.deleted[rel] = true
+		c.discard(rel)
+	}
+
+	if _, ok := c.entries[rel]; !ok {
+		c.keys = append(c.keys, rel)
+	}
+	c.entries[rel] = m
+}
+
+// discard the changes beneath rel.
+func (c *changes) discard(rel string) {
+	keys := c.keys[:0]
+	for _, k := range c.keys {
+		if strings.HasPrefix(k, rel+"/") {
+			delete(c.entries, k)
+			continue
+		}
+		keys = append(keys, k)
+	}
+	c.keys = keys
+}
+
+// makeDir creates a directory, replacing a file in its place.
+func makeDir(path string) error {
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+
+	return os.MkdirAll(path, 0755)
+}
+
+// writeFile atomically writes the contents of r to path by writing to a
+// temporary file in the same directory before renaming it.
+func writeFile(path string, r io.Reader) error {
+	dir := filepath.Dir(path)
+
+	if err := makeDir(dir); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(dir, "."+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		if err := os.RemoveAll(path); err != nil {
+			os.Remove(f.Name())
+			return err
+		}
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// isReset returns true if err indicates the cursor has expired.
+func isReset(err error) bool {
+	e, ok := err.(*dropbox.Error)
+	return ok && strings.HasPrefix(e.Summary, "reset")
+}
@@ -0,0 +1,44 @@
+package dirsync
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tj/go-dropbox"
+)
+
+func TestChanges(t *testing.T) {
+	c := newChanges()
+	c.add("a", &dropbox.Metadata{Tag: "folder"})
+	c.add("a/b.txt", &dropbox.Metadata{Tag: "file", Rev: "1"})
+	c.add("c.txt", &dropbox.Metadata{Tag: "file", Rev: "1"})
+	c.add("a", &dropbox.Metadata{Tag: "deleted"})
+	c.add("c.txt", &dropbox.Metadata{Tag: "file", Rev: "2"})
+	c.add("a/d.txt", &dropbox.Metadata{Tag: "file", Rev: "1"})
+
+	assert.Equal(t, []string{"a", "c.txt", "a/d.txt"}, c.keys)
+	assert.Equal(t, "deleted", c.entries["a"].Tag)
+	assert.Equal(t, "2", c.entries["c.txt"].Rev)
+	assert.Nil(t, c.entries["a/b.txt"])
+}
+
+func TestPull_Apply_state(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dirsync")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	p := &Pull{Local: filepath.Join(dir, "new")}
+	assert.NoError(t, p.Apply(&Plan{cursor: "cursor"}), "should create the local directory")
+
+	state, err := LoadState(filepath.Join(dir, "new", StateFilename))
+	assert.NoError(t, err)
+	assert.Equal(t, "pull", state.Mode)
+	assert.Equal(t, "cursor", state.Cursor)
+
+	s := &Sync{Local: p.Local}
+	_, err = s.Plan()
+	assert.Error(t, err, "should not share the pull state")
+}
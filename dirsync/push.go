@@ -0,0 +1,141 @@
+package dirsync
+
+import (
+	"github.com/tj/go-dropbox"
+)
+
+// Push mirrors a local directory to a Dropbox folder. Files missing or
+// differing remotely are uploaded, and when Delete is set remote files
+// missing locally are removed.
+type Push struct {
+	Files  *dropbox.Files
+	Local  string // local directory
+	Remote string // Dropbox folder, "" or "/" for the root
+
+	// Delete remote files and folders which do not exist locally.
+	Delete bool
+
+	// DryRun plans changes without applying them.
+	DryRun bool
+
+	// Concurrency is the number of parallel uploads, defaulting to DefaultConcurrency.
+	Concurrency int
+}
+
+// Run plans and applies the changes, returning the plan.
+func (p *Push) Run() (*Plan, error) {
+	plan, err := p.Plan()
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, p.Apply(plan)
+}
+
+// Plan compares the local and remote trees and returns the changes required.
+func (p *Push) Plan() (*Plan, error) {
+	local, err := walkLocal(p.Local)
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := listRemote(p.Files, p.Remote)
+	if err != nil {
+		return nil, err
+	}
+
+	actions, err := diffPush(local, remote, p.Delete, func(f *localFile) (string, error) {
+		return dropbox.FileContentHash(f.Abs)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Plan{Actions: actions}, nil
+}
+
+// Apply the plan, removing remote files before uploading. Nothing is
+// changed when DryRun is set.
+func (p *Push) Apply(plan *Plan) error {
+	if p.DryRun {
+		return nil
+	}
+
+	if err := each(plan.Filter(DeleteRemote), p.Concurrency, p.delete); err != nil {
+		return err
+	}
+
+	return each(plan.Filter(Upload), p.Concurrency, p.upload)
+}
+
+// delete a remote file or folder.
+func (p *Push) delete(a *Action) error {
+	_, err := p.Files.Delete(&dropbox.DeleteInput{
+		Path: remotePath(p.Remote, a.Path),
+	})
+
+	if isNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+// upload a local file.
+func (p *Push) upload(a *Action) error {
+	_, err := uploadFile(p.Files, localPath(p.Local, a.Path), remotePath(p.Remote, a.Path), dropbox.WriteModeOverwrite)
+	return err
+}
+
+// diffPush returns the actions required to make remote match local.
+func diffPush(local map[string]*localFile, remote map[string]*dropbox.Metadata, del bool, hash func(*localFile) (string, error)) ([]*Action, error) {
+	var deletes, uploads []*Action
+	deleted := make(map[string]bool)
+
+	// remote entries in the way of a local file or directory, or missing locally
+	for _, k := range remoteKeys(remote) {
+		r := remote[k]
+
+		if isUnder(k, deleted) {
+			continue
+		}
+
+		l, ok := local[k]
+		folder := r.Tag == "folder"
+
+		switch {
+		case ok && l.Dir == folder:
+			continue
+		case !ok && !del:
+			continue
+		}
+
+		deleted[k] = true
+		deletes = append(deletes, &Action{Op: DeleteRemote, Path: displayPath(k, r)})
+	}
+
+	// local files missing or differing remotely
+	for _, k := range localKeys(local) {
+		l := local[k]
+		if l.Dir {
+			continue
+		}
+
+		r, ok := remote[k]
+		if ok && !deleted[k] && !isUnder(k, deleted) && r.Size == uint64(l.Size) {
+			h, err := hash(l)
+			if err != nil {
+				return nil, err
+			}
+
+			if h == r.ContentHash {
+				continue
+			}
+		}
+
+		uploads = append(uploads, &Action{Op: Upload, Path: l.Path, Size: l.Size})
+	}
+
+	return append(deletes, uploads...), nil
+}
@@ -0,0 +1,79 @@
+package dirsync
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tj/go-dropbox"
+)
+
+func TestDiffPush(t *testing.T) {
+	local := map[string]*localFile{
+		"same.txt":     {Path: "same.txt", Size: 3},
+		"changed.txt":  {Path: "changed.txt", Size: 3},
+		"resized.txt":  {Path: "resized.txt", Size: 5},
+		"new.txt":      {Path: "New.txt", Size: 1},
+		"dir":          {Path: "dir", Dir: true},
+		"dir/file.txt": {Path: "dir/file.txt", Size: 2},
+		"was-folder":   {Path: "was-folder", Size: 2},
+	}
+
+	remote := map[string]*dropbox.Metadata{
+		"same.txt":         {Tag: "file", Size: 3, ContentHash: "abc"},
+		"changed.txt":      {Tag: "file", Size: 3, ContentHash: "old"},
+		"resized.txt":      {Tag: "file", Size: 3, ContentHash: "abc"},
+		"dir":              {Tag: "folder"},
+		"extra":            {Tag: "folder", PathLower: "/extra", PathDisplay: "/Extra"},
+		"extra/file.txt":   {Tag: "file", Size: 1},
+		"was-folder":       {Tag: "folder"},
+		"was-folder/a.txt": {Tag: "file", Size: 1},
+		"dir/removed.txt":  {Tag: "file", Size: 1, PathLower: "/dir/removed.txt", PathDisplay: "/dir/Removed.txt"},
+	}
+
+	hash := func(f *localFile) (string, error) {
+		return "abc", nil
+	}
+
+	t.Run("without delete", func(t *testing.T) {
+		actions, err := diffPush(local, remote, false, hash)
+		assert.NoError(t, err)
+		assert.Equal(t, []*Action{
+			{Op: DeleteRemote, Path: "was-folder"},
+			{Op: Upload, Path: "changed.txt", Size: 3},
+			{Op: Upload, Path: "dir/file.txt", Size: 2},
+			{Op: Upload, Path: "New.txt", Size: 1},
+			{Op: Upload, Path: "resized.txt", Size: 5},
+			{Op: Upload, Path: "was-folder", Size: 2},
+		}, actions)
+	})
+
+	t.Run("with delete", func(t *testing.T) {
+		actions, err := diffPush(local, remote, true, hash)
+		assert.NoError(t, err)
+		assert.Equal(t, []*Action{
+			{Op: DeleteRemote, Path: "dir/Removed.txt"},
+			{Op: DeleteRemote, Path: "Extra"},
+			{Op: DeleteRemote, Path: "was-folder"},
+		}, (&Plan{Actions: actions}).Filter(DeleteRemote))
+	})
+}
+
+func TestPush_Plan_missingLocal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dirsync")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	p := &Push{Local: filepath.Join(dir, "typo"), Remote: "/backup", Delete: true}
+	_, err = p.Plan()
+	assert.True(t, os.IsNotExist(err), "should fail for a missing local directory")
+
+	file := filepath.Join(dir, "file")
+	assert.NoError(t, ioutil.WriteFile(file, nil, 0644))
+
+	p.Local = file
+	_, err = p.Plan()
+	assert.Error(t, err, "should fail for a local file")
+}
@@ -0,0 +1,115 @@
+package dirsync
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// StateFilename is the default name of the state file kept in the local directory.
+const StateFilename = ".dropbox-sync"
+
+// FileState is the last synced state of a file. The local size and
+// modification time allow the content hash of unchanged local files
+// to be reused.
+type FileState struct {
+	Rev         string    `json:"rev"`
+	ContentHash string    `json:"content_hash"`
+	Size        int64     `json:"size,omitempty"`
+	ModTime     time.Time `json:"mod_time,omitempty"`
+}
+
+// State persisted between syncs, keyed by lower-cased relative path.
+// Mode records whether the state belongs to a Pull or a Sync, as they
+// track different information and cannot share a state file.
+type State struct {
+	Mode   string                `json:"mode,omitempty"`
+	Cursor string                `json:"cursor"`
+	Files  map[string]*FileState `json:"files"`
+}
+
+// State modes.
+const (
+	modePull = "pull"
+	modeSync = "sync"
+)
+
+// LoadState reads the state from path, returning an empty state if it does not exist.
+func LoadState(path string) (*State, error) {
+	s := &State{Files: make(map[string]*FileState)}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, err
+	}
+
+	if s.Files == nil {
+		s.Files = make(map[string]*FileState)
+	}
+
+	return s, nil
+}
+
+// loadState reads the state from path, failing if it was written in
+// another mode.
+func loadState(path, mode string) (*State, error) {
+	s, err := LoadState(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Mode != "" && s.Mode != mode {
+		return nil, fmt.Errorf("dirsync: %s holds %s state, use a separate StateFile for %s", path, s.Mode, mode)
+	}
+
+	s.Mode = mode
+	return s, nil
+}
+
+// Save writes the state to path atomically.
+func (s *State) Save(path string) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// Remove the state of rel and everything beneath it.
+func (s *State) Remove(rel string) {
+	rel = strings.ToLower(rel)
+	for k := range s.Files {
+		if k == rel || strings.HasPrefix(k, rel+"/") {
+			delete(s.Files, k)
+		}
+	}
+}
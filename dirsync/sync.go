@@ -0,0 +1,470 @@
+package dirsync
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tj/go-dropbox"
+)
+
+// Policy determines how a file changed on both sides is resolved.
+type Policy string
+
+// Supported policies.
+const (
+	KeepBoth   Policy = "keep both"
+	RemoteWins Policy = "remote wins"
+	LocalWins  Policy = "local wins"
+)
+
+// DefaultConflictSuffix is appended to the name of the local copy of a conflicting file.
+const DefaultConflictSuffix = " (conflicted copy)"
+
+// Conflict is the Op renaming the local copy of a conflicting file and uploading it.
+const Conflict Op = "conflict"
+
+// ConflictError is returned when a remote file changed after it was planned.
+type ConflictError struct {
+	Path string
+}
+
+// Error implementation.
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("dirsync: %s changed remotely", e.Path)
+}
+
+// TypeConflictError is returned when a path is a file on one side and a
+// directory on the other, and the file changed since the last sync.
+type TypeConflictError struct {
+	Path string
+}
+
+// Error implementation.
+func (e *TypeConflictError) Error() string {
+	return fmt.Sprintf("dirsync: %s is a file on one side and a directory on the other", e.Path)
+}
+
+// Sync synchronizes a local directory and a Dropbox folder in both
+// directions. The revision and content hash of each file is recorded
+// in the state file, so that changes made on either side since the last
+// sync are detected. Files changed on both sides are resolved by Policy.
+//
+// Only files are synchronized, directories are created as needed for the
+// files within them, and empty directories are neither created nor removed.
+type Sync struct {
+	Files  *dropbox.Files
+	Local  string // local directory
+	Remote string // Dropbox folder, "" or "/" for the root
+
+	// StateFile holding the synced file states, defaulting to
+	// StateFilename within the local directory.
+	// It cannot be shared with a Pull.
+	StateFile string
+
+	// Policy for conflicting changes, defaulting to KeepBoth.
+	Policy Policy
+
+	// ConflictSuffix for the local copy of conflicting files kept by
+	// KeepBoth, defaulting to DefaultConflictSuffix.
+	ConflictSuffix string
+
+	// DryRun plans changes without applying them.
+	DryRun bool
+
+	// Concurrency is the number of parallel transfers, defaulting to DefaultConcurrency.
+	Concurrency int
+
+	mu    sync.Mutex
+	state map[string]*FileState
+}
+
+// Run plans and applies the changes, returning the plan.
+func (s *Sync) Run() (*Plan, error) {
+	plan, err := s.Plan()
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, s.Apply(plan)
+}
+
+// Plan compares both trees with the last synced state and returns the changes required.
+func (s *Sync) Plan() (*Plan, error) {
+	state, err := loadState(s.stateFile(), modeSync)
+	if err != nil {
+		return nil, err
+	}
+
+	local, err := walkLocal(s.Local)
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := listRemote(s.Files, s.Remote)
+	if err != nil {
+		return nil, err
+	}
+
+	// a missing remote would otherwise delete every previously synced local file
+	if remote == nil && len(state.Files) > 0 {
+		return nil, fmt.Errorf("dirsync: %s does not exist but was synced before, remove %s to start over", s.Remote, s.stateFile())
+	}
+
+	return s.diff(state, local, remote)
+}
+
+// diff returns the changes required to synchronize the trees from the
+// last synced state.
+func (s *Sync) diff(state *State, local map[string]*localFile, remote map[string]*dropbox.Metadata) (*Plan, error) {
+	keys := make(map[string]bool)
+
+	for k, l := range local {
+		if !l.Dir {
+			keys[k] = true
+		}
+	}
+
+	for k, r := range remote {
+		if r.Tag == "file" {
+			keys[k] = true
+		}
+	}
+
+	plan := &Plan{synced: make(map[string]*FileState)}
+	names := newNames(s.Local)
+
+	var err error
+
+	for _, k := range sortedSet(keys) {
+		base := state.Files[k]
+
+		l := local[k]
+		if l != nil && l.Dir {
+			l = nil
+		}
+
+		r := remote[k]
+		if r != nil && r.Tag != "file" {
+			r = nil
+		}
+
+		var hash string
+		if l != nil {
+			if hash, err = localHash(l, base); err != nil {
+				return nil, err
+			}
+		}
+
+		rel := k
+		if l != nil {
+			rel = l.Path
+		} else if r != nil {
+			rel = names.resolve(k, r)
+		}
+
+		// a path which is a file on one side and a directory on the other is
+		// only resolved when the file is unchanged, otherwise the directory
+		// would replace changes to the file or the file would replace the
+		// directory's contents
+		if clash(local[k], remote[k]) {
+			unchanged := base != nil && (l != nil && hash == base.ContentHash || r != nil && r.ContentHash == base.ContentHash)
+			if !unchanged {
+				return nil, &TypeConflictError{Path: rel}
+			}
+		}
+
+		// the previous state is kept until a change is applied
+		if base != nil {
+			plan.synced[k] = base
+		}
+
+		localChanged := base == nil && l != nil || base != nil && (l == nil || hash != base.ContentHash)
+		remoteChanged := base == nil && r != nil || base != nil && (r == nil || r.ContentHash != base.ContentHash)
+
+		switch {
+		case !localChanged && !remoteChanged:
+		case localChanged && !remoteChanged:
+			plan.push(rel, l, r)
+		case !localChanged && remoteChanged:
+			plan.pull(rel, l, r)
+		case l == nil && r == nil:
+			delete(plan.synced, k)
+		case l != nil && r != nil && hash == r.ContentHash:
+			plan.synced[k] = &FileState{
+				Rev:         r.Rev,
+				ContentHash: hash,
+				Size:        l.Size,
+				ModTime:     l.ModTime,
+			}
+		default:
+			s.resolve(plan, rel, l, r, local)
+		}
+	}
+
+	return plan, nil
+}
+
+// resolve a file changed on both sides according to the policy.
+func (s *Sync) resolve(plan *Plan, rel string, l *localFile, r *dropbox.Metadata, local map[string]*localFile) {
+	switch s.Policy {
+	case RemoteWins:
+		plan.pull(rel, l, r)
+	case LocalWins:
+		plan.push(rel, l, r)
+	default:
+		if l == nil {
+			plan.pull(rel, l, r)
+			return
+		}
+
+		if r == nil {
+			plan.push(rel, l, r)
+			return
+		}
+
+		plan.Actions = append(plan.Actions, &Action{
+			Op:   Conflict,
+			Path: rel,
+			Size: l.Size,
+			to:   conflictName(rel, s.conflictSuffix(), local),
+		})
+		plan.pull(rel, nil, r)
+	}
+}
+
+// Apply the plan and save the new state. Conflicting local files are
+// renamed first, then deletions are made before the remaining transfers.
+// Uploads replace only the revision seen when planning, failing with
+// a ConflictError otherwise. Nothing is changed when DryRun is set.
+func (s *Sync) Apply(plan *Plan) error {
+	if s.DryRun {
+		return nil
+	}
+
+	s.state = make(map[string]*FileState)
+	for k, f := range plan.synced {
+		s.state[k] = f
+	}
+
+	steps := []struct {
+		op Op
+		fn func(*Action) error
+	}{
+		{Conflict, s.conflict},
+		{DeleteRemote, s.deleteRemote},
+		{DeleteLocal, s.deleteLocal},
+		{Upload, s.upload},
+		{Download, s.download},
+	}
+
+	var err error
+	for _, step := range steps {
+		if err = each(plan.Filter(step.op), s.Concurrency, step.fn); err != nil {
+			break
+		}
+	}
+
+	// state is saved even on failure so completed transfers are not repeated
+	state := &State{Mode: modeSync, Files: s.state}
+	if serr := state.Save(s.stateFile()); err == nil {
+		err = serr
+	}
+
+	return err
+}
+
+// conflict renames the local copy of a conflicting file and uploads it.
+func (s *Sync) conflict(a *Action) error {
+	if err := os.Rename(localPath(s.Local, a.Path), localPath(s.Local, a.to)); err != nil {
+		return err
+	}
+
+	return s.upload(&Action{Op: Upload, Path: a.to})
+}
+
+// deleteRemote removes a remote file.
+func (s *Sync) deleteRemote(a *Action) error {
+	remote := remotePath(s.Remote, a.Path)
+
+	_, err := s.Files.Delete(&dropbox.DeleteInput{
+		Path:      remote,
+		ParentRev: a.rev,
+	})
+
+	if _, ok := err.(*dropbox.WriteConflictError); ok {
+		return &ConflictError{Path: remote}
+	}
+
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+
+	s.record(a.Path, nil)
+	return nil
+}
+
+// deleteLocal removes a local file.
+func (s *Sync) deleteLocal(a *Action) error {
+	if err := os.Remove(localPath(s.Local, a.Path)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	s.record(a.Path, nil)
+	return nil
+}
+
+// upload a local file, replacing the revision seen when planning.
+func (s *Sync) upload(a *Action) error {
+	remote := remotePath(s.Remote, a.Path)
+
+	mode := dropbox.WriteModeAdd
+	if a.rev != "" {
+		mode = dropbox.WriteModeUpdate(a.rev)
+	}
+
+	m, err := uploadFile(s.Files, localPath(s.Local, a.Path), remote, mode)
+	if _, ok := err.(*dropbox.WriteConflictError); ok {
+		return &ConflictError{Path: remote}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return s.recordLocal(a.Path, m)
+}
+
+// download a remote file.
+func (s *Sync) download(a *Action) error {
+	out, err := s.Files.Download(&dropbox.DownloadInput{
+		Path: remotePath(s.Remote, a.Path),
+	})
+	if err != nil {
+		return err
+	}
+	defer out.Body.Close()
+
+	if err := writeFile(localPath(s.Local, a.Path), out.Body); err != nil {
+		return err
+	}
+
+	return s.recordLocal(a.Path, a.meta)
+}
+
+// recordLocal records the synced state of a file using its local size and modification time.
+func (s *Sync) recordLocal(rel string, m *dropbox.Metadata) error {
+	info, err := os.Stat(localPath(s.Local, rel))
+	if err != nil {
+		return err
+	}
+
+	s.record(rel, &FileState{
+		Rev:         m.Rev,
+		ContentHash: m.ContentHash,
+		Size:        info.Size(),
+		ModTime:     info.ModTime(),
+	})
+
+	return nil
+}
+
+// record the synced state of a file, or forget it when f is nil.
+func (s *Sync) record(rel string, f *FileState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f == nil {
+		delete(s.state, strings.ToLower(rel))
+		return
+	}
+
+	s.state[strings.ToLower(rel)] = f
+}
+
+// stateFile returns the path of the state file.
+func (s *Sync) stateFile() string {
+	if s.StateFile != "" {
+		return s.StateFile
+	}
+	return filepath.Join(s.Local, StateFilename)
+}
+
+// conflictSuffix returns the suffix for conflicting copies.
+func (s *Sync) conflictSuffix() string {
+	if s.ConflictSuffix != "" {
+		return s.ConflictSuffix
+	}
+	return DefaultConflictSuffix
+}
+
+// push plans the local state of a file to be copied remotely.
+func (p *Plan) push(rel string, l *localFile, r *dropbox.Metadata) {
+	var rev string
+	if r != nil {
+		rev = r.Rev
+	}
+
+	if l == nil {
+		if r != nil {
+			p.Actions = append(p.Actions, &Action{Op: DeleteRemote, Path: rel, rev: rev})
+		}
+		return
+	}
+
+	p.Actions = append(p.Actions, &Action{Op: Upload, Path: rel, Size: l.Size, rev: rev})
+}
+
+// pull plans the remote state of a file to be copied locally.
+func (p *Plan) pull(rel string, l *localFile, r *dropbox.Metadata) {
+	if r == nil {
+		if l != nil {
+			p.Actions = append(p.Actions, &Action{Op: DeleteLocal, Path: rel})
+		}
+		return
+	}
+
+	p.Actions = append(p.Actions, &Action{Op: Download, Path: rel, Size: int64(r.Size), meta: r})
+}
+
+// clash returns true if a path is a directory on one side and a file on the other.
+func clash(l *localFile, r *dropbox.Metadata) bool {
+	return l != nil && r != nil && l.Dir != (r.Tag == "folder")
+}
+
+// localHash returns the content hash of a local file, reusing the
+// synced hash if its size and modification time are unchanged.
+func localHash(l *localFile, base *FileState) (string, error) {
+	if base != nil && base.Size == l.Size && base.ModTime.Equal(l.ModTime) {
+		return base.ContentHash, nil
+	}
+
+	return dropbox.FileContentHash(l.Abs)
+}
+
+// conflictName returns a name for the conflicting copy of rel which does
+// not exist locally, such as "notes (conflicted copy).txt".
+func conflictName(rel, suffix string, local map[string]*localFile) string {
+	ext := path.Ext(rel)
+	base := strings.TrimSuffix(rel, ext)
+
+	name := base + suffix + ext
+	for i := 2; local[strings.ToLower(name)] != nil; i++ {
+		name = fmt.Sprintf("%s%s %d%s", base, suffix, i, ext)
+	}
+
+	return name
+}
+
+// sortedSet returns the members of the set in order.
+func sortedSet(set map[string]bool) (keys []string) {
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return
+}
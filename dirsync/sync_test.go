@@ -0,0 +1,115 @@
+package dirsync
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tj/go-dropbox"
+)
+
+func TestConflictName(t *testing.T) {
+	local := map[string]*localFile{
+		"notes.txt":                       {Path: "notes.txt"},
+		"docs/notes (conflicted copy).md": {Path: "docs/notes (conflicted copy).md"},
+	}
+
+	assert.Equal(t, "notes (conflicted copy).txt", conflictName("notes.txt", DefaultConflictSuffix, local))
+	assert.Equal(t, "docs/Notes (conflicted copy) 2.md", conflictName("docs/Notes.md", DefaultConflictSuffix, local))
+	assert.Equal(t, "Makefile.conflict", conflictName("Makefile", ".conflict", local))
+}
+
+func TestSync_Plan_missingLocal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dirsync")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s := &Sync{
+		Local:     filepath.Join(dir, "typo"),
+		Remote:    "/backup",
+		StateFile: filepath.Join(dir, "state"),
+	}
+
+	_, err = s.Plan()
+	assert.True(t, os.IsNotExist(err), "should fail for a missing local directory")
+}
+
+func TestSync_Plan_missingRemote(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dirsync")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	client := dropbox.New(&dropbox.Config{
+		HTTPClient: &http.Client{
+			Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusConflict,
+					Body:       ioutil.NopCloser(strings.NewReader(`{"error_summary":"path/not_found/.."}`)),
+				}, nil
+			}),
+		},
+	})
+
+	s := &Sync{
+		Files:     client.Files,
+		Local:     dir,
+		Remote:    "/backup",
+		StateFile: filepath.Join(dir, "state"),
+	}
+
+	plan, err := s.Plan()
+	assert.NoError(t, err, "should treat a missing remote as empty on the first sync")
+	assert.True(t, plan.Empty())
+
+	state := &State{
+		Mode:  modeSync,
+		Files: map[string]*FileState{"notes.txt": {Rev: "1"}},
+	}
+	assert.NoError(t, state.Save(s.StateFile))
+
+	_, err = s.Plan()
+	assert.Error(t, err, "should refuse to plan against a missing remote which was synced before")
+}
+
+// roundTripFunc is an http.RoundTripper calling itself.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implementation.
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestSync_diff_typeConflict(t *testing.T) {
+	s := &Sync{}
+
+	local := map[string]*localFile{
+		"a": {Path: "a", Dir: true},
+	}
+
+	remote := map[string]*dropbox.Metadata{
+		"a": {Tag: "file", Rev: "2", ContentHash: "new"},
+	}
+
+	t.Run("file unchanged", func(t *testing.T) {
+		state := &State{Files: map[string]*FileState{"a": {Rev: "2", ContentHash: "new"}}}
+		plan, err := s.diff(state, local, remote)
+		assert.NoError(t, err)
+		assert.Equal(t, []*Action{{Op: DeleteRemote, Path: "a", rev: "2"}}, plan.Actions)
+	})
+
+	t.Run("file changed", func(t *testing.T) {
+		state := &State{Files: map[string]*FileState{"a": {Rev: "1", ContentHash: "old"}}}
+		_, err := s.diff(state, local, remote)
+		assert.Equal(t, &TypeConflictError{Path: "a"}, err)
+	})
+
+	t.Run("never synced", func(t *testing.T) {
+		state := &State{Files: map[string]*FileState{}}
+		_, err := s.diff(state, local, remote)
+		assert.Equal(t, &TypeConflictError{Path: "a"}, err)
+	})
+}
@@ -1,5 +1,11 @@
 package dropbox
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
 // Error response.
 type Error struct {
 	Status     string
@@ -11,3 +17,75 @@ type Error struct {
 func (e *Error) Error() string {
 	return e.Summary
 }
+
+// unionError returns an Error summarizing the tags of an error union,
+// such as "path/not_found", for errors reported within a response.
+func unionError(raw json.RawMessage) *Error {
+	var tags []string
+
+	for len(raw) > 0 {
+		var u map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &u); err != nil {
+			break
+		}
+
+		var tag string
+		if err := json.Unmarshal(u[".tag"], &tag); err != nil || tag == "" {
+			break
+		}
+
+		tags = append(tags, tag)
+		raw = u[tag]
+	}
+
+	return &Error{Summary: strings.Join(tags, "/")}
+}
+
+// WriteConflictError is returned when a write conflicts with an existing
+// file or folder, including when the revision given to WriteModeUpdate
+// is no longer the latest.
+type WriteConflictError struct {
+	Err      *Error
+	Conflict string // file, folder, file_ancestor or other
+}
+
+// Error string.
+func (e *WriteConflictError) Error() string {
+	return e.Err.Error()
+}
+
+// writeError returns a WriteConflictError for conflicting writes, or err.
+func writeError(err error) error {
+	e, ok := err.(*Error)
+	if !ok {
+		return err
+	}
+
+	i := strings.Index(e.Summary, "conflict/")
+	if i == -1 {
+		return err
+	}
+
+	conflict := e.Summary[i+len("conflict/"):]
+	if i := strings.IndexAny(conflict, "/."); i != -1 {
+		conflict = conflict[:i]
+	}
+
+	return &WriteConflictError{
+		Err:      e,
+		Conflict: conflict,
+	}
+}
+
+// ContentHashError is returned when transferred data does not match the
+// content_hash reported by Dropbox.
+type ContentHashError struct {
+	Path     string
+	Expected string
+	Actual   string
+}
+
+// Error string.
+func (e *ContentHashError) Error() string {
+	return fmt.Sprintf("dropbox: content hash mismatch for %s: expected %s, got %s", e.Path, e.Expected, e.Actual)
+}
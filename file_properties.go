@@ -0,0 +1,260 @@
+package dropbox
+
+import (
+	"encoding/json"
+)
+
+// FileProperties client for custom file properties and property templates.
+type FileProperties struct {
+	*Client
+}
+
+// NewFileProperties client.
+func NewFileProperties(config *Config) *FileProperties {
+	return &FileProperties{
+		Client: &Client{
+			Config: config,
+		},
+	}
+}
+
+// PropertyType determines the type of a property field.
+type PropertyType string
+
+// Supported property types.
+const (
+	PropertyTypeString PropertyType = "string"
+)
+
+// PropertyFieldTemplate describes a field of a property template.
+type PropertyFieldTemplate struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Type        PropertyType `json:"type"`
+}
+
+// PropertyField is the value of a property field.
+type PropertyField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// PropertyGroup is a set of property fields belonging to a template.
+type PropertyGroup struct {
+	TemplateID string           `json:"template_id"`
+	Fields     []*PropertyField `json:"fields"`
+}
+
+// TemplateFilter selects property templates.
+type TemplateFilter struct {
+	Tag        string   `json:".tag"`
+	FilterSome []string `json:"filter_some,omitempty"`
+}
+
+// TemplateFilterNone excludes all templates, only valid when searching.
+var TemplateFilterNone = &TemplateFilter{Tag: "filter_none"}
+
+// TemplateFilterSome returns a filter for the given template IDs.
+func TemplateFilterSome(templateIDs ...string) *TemplateFilter {
+	return &TemplateFilter{Tag: "filter_some", FilterSome: templateIDs}
+}
+
+// TemplatesAddForUserInput request input.
+type TemplatesAddForUserInput struct {
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Fields      []*PropertyFieldTemplate `json:"fields"`
+}
+
+// TemplatesAddForUserOutput request output.
+type TemplatesAddForUserOutput struct {
+	TemplateID string `json:"template_id"`
+}
+
+// TemplatesAddForUser adds a property template for the current user.
+func (c *FileProperties) TemplatesAddForUser(in *TemplatesAddForUserInput) (out *TemplatesAddForUserOutput, err error) {
+	body, err := c.call("/file_properties/templates/add_for_user", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// TemplatesListForUserOutput request output.
+type TemplatesListForUserOutput struct {
+	TemplateIDs []string `json:"template_ids"`
+}
+
+// TemplatesListForUser returns the IDs of the current user's property templates.
+func (c *FileProperties) TemplatesListForUser() (out *TemplatesListForUserOutput, err error) {
+	body, err := c.call("/file_properties/templates/list_for_user", nil)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// TemplatesRemoveForUserInput request input.
+type TemplatesRemoveForUserInput struct {
+	TemplateID string `json:"template_id"`
+}
+
+// TemplatesRemoveForUser permanently removes a property template of the
+// current user, along with the property groups using it.
+func (c *FileProperties) TemplatesRemoveForUser(in *TemplatesRemoveForUserInput) (err error) {
+	body, err := c.call("/file_properties/templates/remove_for_user", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	return
+}
+
+// PropertiesAddInput request input.
+type PropertiesAddInput struct {
+	Path           string           `json:"path"`
+	PropertyGroups []*PropertyGroup `json:"property_groups"`
+}
+
+// PropertiesAdd adds property groups to a file.
+func (c *FileProperties) PropertiesAdd(in *PropertiesAddInput) (err error) {
+	body, err := c.call("/file_properties/properties/add", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	return
+}
+
+// PropertiesOverwriteInput request input.
+type PropertiesOverwriteInput struct {
+	Path           string           `json:"path"`
+	PropertyGroups []*PropertyGroup `json:"property_groups"`
+}
+
+// PropertiesOverwrite replaces the fields of property groups on a file.
+func (c *FileProperties) PropertiesOverwrite(in *PropertiesOverwriteInput) (err error) {
+	body, err := c.call("/file_properties/properties/overwrite", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	return
+}
+
+// PropertyGroupUpdate specifies fields to add, update or remove from a property group.
+type PropertyGroupUpdate struct {
+	TemplateID        string           `json:"template_id"`
+	AddOrUpdateFields []*PropertyField `json:"add_or_update_fields,omitempty"`
+	RemoveFields      []string         `json:"remove_fields,omitempty"`
+}
+
+// PropertiesUpdateInput request input.
+type PropertiesUpdateInput struct {
+	Path                 string                 `json:"path"`
+	UpdatePropertyGroups []*PropertyGroupUpdate `json:"update_property_groups"`
+}
+
+// PropertiesUpdate adds, updates or removes fields of property groups on a file.
+func (c *FileProperties) PropertiesUpdate(in *PropertiesUpdateInput) (err error) {
+	body, err := c.call("/file_properties/properties/update", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	return
+}
+
+// PropertiesRemoveInput request input.
+type PropertiesRemoveInput struct {
+	Path                string   `json:"path"`
+	PropertyTemplateIDs []string `json:"property_template_ids"`
+}
+
+// PropertiesRemove removes property groups from a file.
+func (c *FileProperties) PropertiesRemove(in *PropertiesRemoveInput) (err error) {
+	body, err := c.call("/file_properties/properties/remove", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	return
+}
+
+// PropertiesSearchMode determines which fields a query matches.
+type PropertiesSearchMode struct {
+	Tag       string `json:".tag"`
+	FieldName string `json:"field_name,omitempty"`
+}
+
+// PropertiesSearchFieldName returns a mode matching the values of the named field.
+func PropertiesSearchFieldName(name string) PropertiesSearchMode {
+	return PropertiesSearchMode{Tag: "field_name", FieldName: name}
+}
+
+// PropertiesSearchQuery is a query against property values.
+type PropertiesSearchQuery struct {
+	Query           string               `json:"query"`
+	Mode            PropertiesSearchMode `json:"mode"`
+	LogicalOperator string               `json:"logical_operator,omitempty"`
+}
+
+// PropertiesSearchInput request input.
+type PropertiesSearchInput struct {
+	Queries        []*PropertiesSearchQuery `json:"queries"`
+	TemplateFilter *TemplateFilter          `json:"template_filter,omitempty"`
+}
+
+// PropertiesSearchMatch is a file with matching properties.
+type PropertiesSearchMatch struct {
+	ID             string           `json:"id"`
+	Path           string           `json:"path"`
+	IsDeleted      bool             `json:"is_deleted"`
+	PropertyGroups []*PropertyGroup `json:"property_groups"`
+}
+
+// PropertiesSearchOutput request output.
+type PropertiesSearchOutput struct {
+	Matches []*PropertiesSearchMatch `json:"matches"`
+	Cursor  string                   `json:"cursor,omitempty"`
+}
+
+// PropertiesSearch searches for files with property values matching the queries.
+func (c *FileProperties) PropertiesSearch(in *PropertiesSearchInput) (out *PropertiesSearchOutput, err error) {
+	body, err := c.call("/file_properties/properties/search", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// PropertiesSearchContinueInput request input.
+type PropertiesSearchContinueInput struct {
+	Cursor string `json:"cursor"`
+}
+
+// PropertiesSearchContinue paginates using the cursor from PropertiesSearch.
+func (c *FileProperties) PropertiesSearchContinue(in *PropertiesSearchContinueInput) (out *PropertiesSearchOutput, err error) {
+	body, err := c.call("/file_properties/properties/search/continue", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
@@ -0,0 +1,59 @@
+package dropbox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileProperties(t *testing.T) {
+	c := client()
+
+	tmpl, err := c.FileProperties.TemplatesAddForUser(&TemplatesAddForUserInput{
+		Name:        "Project",
+		Description: "Project metadata",
+		Fields: []*PropertyFieldTemplate{
+			{Name: "customer_id", Description: "Customer ID", Type: PropertyTypeString},
+		},
+	})
+	assert.NoError(t, err)
+
+	id := tmpl.TemplateID
+
+	templates, err := c.FileProperties.TemplatesListForUser()
+	assert.NoError(t, err)
+	assert.Contains(t, templates.TemplateIDs, id)
+
+	err = c.FileProperties.PropertiesOverwrite(&PropertiesOverwriteInput{
+		Path: "/hello.txt",
+		PropertyGroups: []*PropertyGroup{
+			{TemplateID: id, Fields: []*PropertyField{{Name: "customer_id", Value: "acme"}}},
+		},
+	})
+	assert.NoError(t, err)
+
+	meta, err := c.Files.GetMetadata(&GetMetadataInput{
+		Path:                  "/hello.txt",
+		IncludePropertyGroups: TemplateFilterSome(id),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, meta.PropertyGroups, 1)
+	assert.Equal(t, "acme", meta.PropertyGroups[0].Fields[0].Value)
+
+	out, err := c.FileProperties.PropertiesSearch(&PropertiesSearchInput{
+		Queries: []*PropertiesSearchQuery{
+			{Query: "acme", Mode: PropertiesSearchFieldName("customer_id")},
+		},
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, out.Matches)
+
+	err = c.FileProperties.PropertiesRemove(&PropertiesRemoveInput{
+		Path:                "/hello.txt",
+		PropertyTemplateIDs: []string{id},
+	})
+	assert.NoError(t, err)
+
+	err = c.FileProperties.TemplatesRemoveForUser(&TemplatesRemoveForUserInput{TemplateID: id})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,255 @@
+package dropbox
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// FileRequests client for file requests.
+type FileRequests struct {
+	*Client
+}
+
+// NewFileRequests client.
+func NewFileRequests(config *Config) *FileRequests {
+	return &FileRequests{
+		Client: &Client{
+			Config: config,
+		},
+	}
+}
+
+// GracePeriod determines how long after the deadline uploads are allowed.
+type GracePeriod string
+
+// Supported grace periods.
+const (
+	GracePeriodOneDay     GracePeriod = "one_day"
+	GracePeriodTwoDays    GracePeriod = "two_days"
+	GracePeriodSevenDays  GracePeriod = "seven_days"
+	GracePeriodThirtyDays GracePeriod = "thirty_days"
+	GracePeriodAlways     GracePeriod = "always"
+)
+
+// FileRequestDeadline is the deadline of a file request.
+type FileRequestDeadline struct {
+	Deadline         time.Time
+	AllowLateUploads GracePeriod
+}
+
+// MarshalJSON implementation.
+func (d *FileRequestDeadline) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Deadline         string      `json:"deadline"`
+		AllowLateUploads GracePeriod `json:"allow_late_uploads,omitempty"`
+	}{timestamp(d.Deadline), d.AllowLateUploads})
+}
+
+// UnmarshalJSON implementation.
+func (d *FileRequestDeadline) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Deadline         time.Time `json:"deadline"`
+		AllowLateUploads struct {
+			Tag GracePeriod `json:".tag"`
+		} `json:"allow_late_uploads"`
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	d.Deadline = v.Deadline
+	d.AllowLateUploads = v.AllowLateUploads.Tag
+	return nil
+}
+
+// FileRequest collects files uploaded by others into a folder.
+type FileRequest struct {
+	ID          string               `json:"id"`
+	URL         string               `json:"url"`
+	Title       string               `json:"title"`
+	Created     time.Time            `json:"created"`
+	IsOpen      bool                 `json:"is_open"`
+	FileCount   int64                `json:"file_count"`
+	Destination string               `json:"destination,omitempty"`
+	Deadline    *FileRequestDeadline `json:"deadline,omitempty"`
+	Description string               `json:"description,omitempty"`
+}
+
+// CreateFileRequestInput request input. Requests are open unless Open is false.
+type CreateFileRequestInput struct {
+	Title       string               `json:"title"`
+	Destination string               `json:"destination"`
+	Deadline    *FileRequestDeadline `json:"deadline,omitempty"`
+	Open        *bool                `json:"open,omitempty"`
+	Description string               `json:"description,omitempty"`
+}
+
+// Create a file request.
+func (c *FileRequests) Create(in *CreateFileRequestInput) (out *FileRequest, err error) {
+	body, err := c.call("/file_requests/create", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// GetFileRequestInput request input.
+type GetFileRequestInput struct {
+	ID string `json:"id"`
+}
+
+// Get a file request.
+func (c *FileRequests) Get(in *GetFileRequestInput) (out *FileRequest, err error) {
+	body, err := c.call("/file_requests/get", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// ListFileRequestsInput request input.
+type ListFileRequestsInput struct {
+	Limit uint64 `json:"limit,omitempty"`
+}
+
+// ListFileRequestsOutput request output.
+type ListFileRequestsOutput struct {
+	FileRequests []*FileRequest `json:"file_requests"`
+	Cursor       string         `json:"cursor"`
+	HasMore      bool           `json:"has_more"`
+}
+
+// ListV2 returns the file requests of the current user.
+func (c *FileRequests) ListV2(in *ListFileRequestsInput) (out *ListFileRequestsOutput, err error) {
+	body, err := c.call("/file_requests/list_v2", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// ListFileRequestsContinueInput request input.
+type ListFileRequestsContinueInput struct {
+	Cursor string `json:"cursor"`
+}
+
+// ListContinue paginates using the cursor from ListV2.
+func (c *FileRequests) ListContinue(in *ListFileRequestsContinueInput) (out *ListFileRequestsOutput, err error) {
+	body, err := c.call("/file_requests/list/continue", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// UpdateFileRequestInput request input. Only the fields set are changed;
+// the deadline is changed when Deadline is set, or removed when
+// RemoveDeadline is true.
+type UpdateFileRequestInput struct {
+	ID             string               `json:"id"`
+	Title          string               `json:"title,omitempty"`
+	Destination    string               `json:"destination,omitempty"`
+	Deadline       *FileRequestDeadline `json:"-"`
+	RemoveDeadline bool                 `json:"-"`
+	Open           *bool                `json:"open,omitempty"`
+	Description    string               `json:"description,omitempty"`
+}
+
+// MarshalJSON implementation.
+func (in *UpdateFileRequestInput) MarshalJSON() ([]byte, error) {
+	type input UpdateFileRequestInput
+
+	deadline := map[string]interface{}{".tag": "no_update"}
+
+	switch {
+	case in.Deadline != nil:
+		deadline[".tag"] = "update"
+		deadline["deadline"] = timestamp(in.Deadline.Deadline)
+		if in.Deadline.AllowLateUploads != "" {
+			deadline["allow_late_uploads"] = in.Deadline.AllowLateUploads
+		}
+	case in.RemoveDeadline:
+		deadline[".tag"] = "update"
+	}
+
+	return json.Marshal(struct {
+		*input
+		Deadline map[string]interface{} `json:"deadline"`
+	}{(*input)(in), deadline})
+}
+
+// Update a file request.
+func (c *FileRequests) Update(in *UpdateFileRequestInput) (out *FileRequest, err error) {
+	body, err := c.call("/file_requests/update", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// DeleteFileRequestsInput request input.
+type DeleteFileRequestsInput struct {
+	IDs []string `json:"ids"`
+}
+
+// DeleteFileRequestsOutput request output.
+type DeleteFileRequestsOutput struct {
+	FileRequests []*FileRequest `json:"file_requests"`
+}
+
+// Delete closed file requests.
+func (c *FileRequests) Delete(in *DeleteFileRequestsInput) (out *DeleteFileRequestsOutput, err error) {
+	body, err := c.call("/file_requests/delete", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// DeleteAllClosed deletes all closed file requests.
+func (c *FileRequests) DeleteAllClosed() (out *DeleteFileRequestsOutput, err error) {
+	body, err := c.call("/file_requests/delete_all_closed", nil)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// CountFileRequestsOutput request output.
+type CountFileRequestsOutput struct {
+	FileRequestCount uint64 `json:"file_request_count"`
+}
+
+// Count returns the number of file requests of the current user.
+func (c *FileRequests) Count() (out *CountFileRequestsOutput, err error) {
+	body, err := c.call("/file_requests/count", nil)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
@@ -0,0 +1,49 @@
+package dropbox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileRequests(t *testing.T) {
+	c := client()
+
+	deadline := time.Now().Add(7 * 24 * time.Hour).Truncate(time.Second)
+
+	req, err := c.FileRequests.Create(&CreateFileRequestInput{
+		Title:       "Invoices",
+		Destination: "/File requests/Invoices",
+		Deadline: &FileRequestDeadline{
+			Deadline:         deadline,
+			AllowLateUploads: GracePeriodOneDay,
+		},
+	})
+	assert.NoError(t, err)
+	assert.True(t, req.IsOpen)
+	assert.True(t, deadline.Equal(req.Deadline.Deadline))
+	assert.Equal(t, GracePeriodOneDay, req.Deadline.AllowLateUploads)
+
+	closed := false
+	req, err = c.FileRequests.Update(&UpdateFileRequestInput{
+		ID:             req.ID,
+		RemoveDeadline: true,
+		Open:           &closed,
+	})
+	assert.NoError(t, err)
+	assert.False(t, req.IsOpen)
+	assert.Nil(t, req.Deadline)
+
+	list, err := c.FileRequests.ListV2(&ListFileRequestsInput{Limit: 1})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, list.FileRequests)
+
+	count, err := c.FileRequests.Count()
+	assert.NoError(t, err)
+	assert.NotZero(t, count.FileRequestCount)
+
+	out, err := c.FileRequests.Delete(&DeleteFileRequestsInput{IDs: []string{req.ID}})
+	assert.NoError(t, err)
+	assert.Len(t, out.FileRequests, 1)
+}
@@ -1,11 +1,11 @@
 package dropbox
 
 import (
-	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"hash"
 	"io"
-	"os"
+	"strings"
 	"time"
 )
 
@@ -24,14 +24,33 @@ func NewFiles(config *Config) *Files {
 }
 
 // WriteMode determines what to do if the file already exists.
-type WriteMode string
+type WriteMode struct {
+	Tag    string `json:".tag"`
+	Update string `json:"update,omitempty"`
+}
 
 // Supported write modes.
-const (
-	WriteModeAdd       WriteMode = "add"
-	WriteModeOverwrite           = "overwrite"
+var (
+	WriteModeAdd       = WriteMode{Tag: "add"}
+	WriteModeOverwrite = WriteMode{Tag: "overwrite"}
 )
 
+// WriteModeUpdate overwrites the file only if it is still at revision rev,
+// otherwise the write fails with a WriteConflictError.
+func WriteModeUpdate(rev string) WriteMode {
+	return WriteMode{Tag: "update", Update: rev}
+}
+
+// MarshalJSON implementation, defaulting to add.
+func (m WriteMode) MarshalJSON() ([]byte, error) {
+	if m.Tag == "" {
+		m = WriteModeAdd
+	}
+
+	type mode WriteMode
+	return json.Marshal(mode(m))
+}
+
 // Dimensions specifies the dimensions of a photo or video.
 type Dimensions struct {
 	Width  uint64 `json:"width"`
@@ -78,6 +97,20 @@ type FileSharingInfo struct {
 	ModifiedBy           string `json:"modified_by,omitempty"`
 }
 
+// ExportInfo for a file which must be exported rather than downloaded.
+type ExportInfo struct {
+	ExportAs      string   `json:"export_as,omitempty"`
+	ExportOptions []string `json:"export_options,omitempty"`
+}
+
+// FileLockInfo for a locked file.
+type FileLockInfo struct {
+	IsLockholder        bool      `json:"is_lockholder"`
+	LockholderName      string    `json:"lockholder_name,omitempty"`
+	LockholderAccountID string    `json:"lockholder_account_id,omitempty"`
+	Created             time.Time `json:"created,omitempty"`
+}
+
 // Metadata for a file or folder.
 type Metadata struct {
 	Tag            string           `json:".tag"`
@@ -92,12 +125,25 @@ type Metadata struct {
 	MediaInfo      *MediaInfo       `json:"media_info,omitempty"`
 	SharingInfo    *FileSharingInfo `json:"sharing_info,omitempty"`
 	ContentHash    string           `json:"content_hash,omitempty"`
+	IsDownloadable *bool            `json:"is_downloadable,omitempty"`
+	ExportInfo     *ExportInfo      `json:"export_info,omitempty"`
+	FileLockInfo   *FileLockInfo    `json:"file_lock_info,omitempty"`
+	PropertyGroups []*PropertyGroup `json:"property_groups,omitempty"`
+}
+
+// Downloadable returns true if the file can be downloaded, which is
+// assumed when IsDownloadable is not reported. Folders are never
+// downloadable, see DownloadZip, and files which are not must be
+// exported instead.
+func (m *Metadata) Downloadable() bool {
+	return m.Tag == "file" && (m.IsDownloadable == nil || *m.IsDownloadable)
 }
 
 // GetMetadataInput request input.
 type GetMetadataInput struct {
-	Path             string `json:"path"`
-	IncludeMediaInfo bool   `json:"include_media_info"`
+	Path                  string          `json:"path"`
+	IncludeMediaInfo      bool            `json:"include_media_info"`
+	IncludePropertyGroups *TemplateFilter `json:"include_property_groups,omitempty"`
 }
 
 // GetMetadataOutput request output.
@@ -143,7 +189,8 @@ func (c *Files) CreateFolder(in *CreateFolderInput) (out *CreateFolderOutput, er
 
 // DeleteInput request input.
 type DeleteInput struct {
-	Path string `json:"path"`
+	Path      string `json:"path"`
+	ParentRev string `json:"parent_rev,omitempty"`
 }
 
 // DeleteOutput request output.
@@ -151,15 +198,26 @@ type DeleteOutput struct {
 	Metadata
 }
 
-// Delete a file or folder and its contents.
+// Delete a file or folder and its contents. When ParentRev is set the file
+// is only deleted if it is still at that revision, otherwise a
+// WriteConflictError is returned.
 func (c *Files) Delete(in *DeleteInput) (out *DeleteOutput, err error) {
-	body, err := c.call("/files/delete", in)
+	body, err := c.call("/files/delete_v2", in)
 	if err != nil {
+		err = writeError(err)
 		return
 	}
 	defer body.Close()
 
-	err = json.NewDecoder(body).Decode(&out)
+	var res struct {
+		Metadata Metadata `json:"metadata"`
+	}
+
+	if err = json.NewDecoder(body).Decode(&res); err != nil {
+		return
+	}
+
+	out = &DeleteOutput{res.Metadata}
 	return
 }
 
@@ -202,6 +260,75 @@ func (c *Files) Copy(in *CopyInput) (out *CopyOutput, err error) {
 	return
 }
 
+// CopyReferenceGetInput request input.
+type CopyReferenceGetInput struct {
+	Path string `json:"path"`
+}
+
+// CopyReferenceGetOutput request output.
+type CopyReferenceGetOutput struct {
+	Metadata      *Metadata `json:"metadata"`
+	CopyReference string    `json:"copy_reference"`
+	Expires       time.Time `json:"expires"`
+}
+
+// CopyReferenceGet returns a copy reference to a file or folder, which
+// may be saved into another user's Dropbox with CopyReferenceSave.
+func (c *Files) CopyReferenceGet(in *CopyReferenceGetInput) (out *CopyReferenceGetOutput, err error) {
+	body, err := c.call("/files/copy_reference/get", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// CopyReferenceSaveInput request input.
+type CopyReferenceSaveInput struct {
+	CopyReference string `json:"copy_reference"`
+	Path          string `json:"path"`
+}
+
+// CopyReferenceSaveOutput request output.
+type CopyReferenceSaveOutput struct {
+	Metadata *Metadata `json:"metadata"`
+}
+
+// CopyReferenceSave saves a copy reference returned by CopyReferenceGet.
+func (c *Files) CopyReferenceSave(in *CopyReferenceSaveInput) (out *CopyReferenceSaveOutput, err error) {
+	body, err := c.call("/files/copy_reference/save", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// CopyBetween copies a file or folder from one account to another using
+// a copy reference, so the contents are not transferred by the client.
+func CopyBetween(from *Files, fromPath string, to *Files, toPath string) (*Metadata, error) {
+	ref, err := from.CopyReferenceGet(&CopyReferenceGetInput{
+		Path: fromPath,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := to.CopyReferenceSave(&CopyReferenceSaveInput{
+		CopyReference: ref.CopyReference,
+		Path:          toPath,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Metadata, nil
+}
+
 // MoveInput request input.
 type MoveInput struct {
 	FromPath string `json:"from_path"`
@@ -250,10 +377,19 @@ func (c *Files) Restore(in *RestoreInput) (out *RestoreOutput, err error) {
 
 // ListFolderInput request input.
 type ListFolderInput struct {
-	Path             string `json:"path"`
-	Recursive        bool   `json:"recursive"`
-	IncludeMediaInfo bool   `json:"include_media_info"`
-	IncludeDeleted   bool   `json:"include_deleted"`
+	Path                  string          `json:"path"`
+	Recursive             bool            `json:"recursive"`
+	IncludeMediaInfo      bool            `json:"include_media_info"`
+	IncludeDeleted        bool            `json:"include_deleted"`
+	IncludePropertyGroups *TemplateFilter `json:"include_property_groups,omitempty"`
+	SharedLink            *SharedLink     `json:"shared_link,omitempty"`
+}
+
+// SharedLink to list the contents of, in which case Path is relative
+// to the shared folder.
+type SharedLink struct {
+	URL      string `json:"url"`
+	Password string `json:"password,omitempty"`
 }
 
 // ListFolderOutput request output.
@@ -294,6 +430,27 @@ func (c *Files) ListFolderContinue(in *ListFolderContinueInput) (out *ListFolder
 	return
 }
 
+// listFolderAll returns every entry of the folder, following cursors.
+func (c *Files) listFolderAll(in *ListFolderInput) (entries []*Metadata, err error) {
+	out, err := c.ListFolder(in)
+
+	for {
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, out.Entries...)
+
+		if !out.HasMore {
+			return
+		}
+
+		out, err = c.ListFolderContinue(&ListFolderContinueInput{
+			Cursor: out.Cursor,
+		})
+	}
+}
+
 // SearchMode determines how a search is performed.
 type SearchMode string
 
@@ -356,6 +513,134 @@ func (c *Files) Search(in *SearchInput) (out *SearchOutput, err error) {
 	return
 }
 
+// SearchOrderBy determines the order of search results.
+type SearchOrderBy string
+
+// Supported search orders.
+const (
+	SearchOrderByRelevance        SearchOrderBy = "relevance"
+	SearchOrderByLastModifiedTime SearchOrderBy = "last_modified_time"
+)
+
+// FileStatus restricts a search to active or deleted files.
+type FileStatus string
+
+// Supported file statuses.
+const (
+	FileStatusActive  FileStatus = "active"
+	FileStatusDeleted FileStatus = "deleted"
+)
+
+// FileCategory restricts a search to a category of files.
+type FileCategory string
+
+// Supported file categories.
+const (
+	FileCategoryImage        FileCategory = "image"
+	FileCategoryDocument     FileCategory = "document"
+	FileCategoryPDF          FileCategory = "pdf"
+	FileCategorySpreadsheet  FileCategory = "spreadsheet"
+	FileCategoryPresentation FileCategory = "presentation"
+	FileCategoryAudio        FileCategory = "audio"
+	FileCategoryVideo        FileCategory = "video"
+	FileCategoryFolder       FileCategory = "folder"
+	FileCategoryPaper        FileCategory = "paper"
+	FileCategoryOthers       FileCategory = "others"
+)
+
+// SearchMatchTypeV2 represents the type of match made by SearchV2.
+type SearchMatchTypeV2 string
+
+// Supported search v2 match types.
+const (
+	SearchMatchTypeFilename           SearchMatchTypeV2 = "filename"
+	SearchMatchTypeFileContent        SearchMatchTypeV2 = "file_content"
+	SearchMatchTypeFilenameAndContent SearchMatchTypeV2 = "filename_and_content"
+	SearchMatchTypeImageContent       SearchMatchTypeV2 = "image_content"
+)
+
+// SearchOptions for SearchV2.
+type SearchOptions struct {
+	Path           string         `json:"path,omitempty"`
+	MaxResults     uint64         `json:"max_results,omitempty"`
+	OrderBy        SearchOrderBy  `json:"order_by,omitempty"`
+	FileStatus     FileStatus     `json:"file_status,omitempty"`
+	FilenameOnly   bool           `json:"filename_only,omitempty"`
+	FileExtensions []string       `json:"file_extensions,omitempty"`
+	FileCategories []FileCategory `json:"file_categories,omitempty"`
+}
+
+// SearchMatchFieldOptions for SearchV2.
+type SearchMatchFieldOptions struct {
+	IncludeHighlights bool `json:"include_highlights"`
+}
+
+// HighlightSpan is a part of a matched file name or content, which is
+// highlighted if it matched the query.
+type HighlightSpan struct {
+	HighlightStr  string `json:"highlight_str"`
+	IsHighlighted bool   `json:"is_highlighted"`
+}
+
+// SearchMatchV2 represents a file or folder matched by SearchV2.
+type SearchMatchV2 struct {
+	Metadata struct {
+		Tag      string    `json:".tag"`
+		Metadata *Metadata `json:"metadata"`
+	} `json:"metadata"`
+	MatchType struct {
+		Tag SearchMatchTypeV2 `json:".tag"`
+	} `json:"match_type"`
+	HighlightSpans []*HighlightSpan `json:"highlight_spans"`
+}
+
+// SearchV2Input request input.
+type SearchV2Input struct {
+	Query             string                   `json:"query"`
+	Options           *SearchOptions           `json:"options,omitempty"`
+	MatchFieldOptions *SearchMatchFieldOptions `json:"match_field_options,omitempty"`
+}
+
+// SearchV2Output request output.
+type SearchV2Output struct {
+	Matches []*SearchMatchV2 `json:"matches"`
+	HasMore bool             `json:"has_more"`
+	Cursor  string           `json:"cursor"`
+}
+
+// SearchV2 searches for files and folders.
+func (c *Files) SearchV2(in *SearchV2Input) (out *SearchV2Output, err error) {
+	if in.Options != nil {
+		in.Options.Path = normalizePath(in.Options.Path)
+	}
+
+	body, err := c.call("/files/search_v2", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// SearchContinueV2Input request input.
+type SearchContinueV2Input struct {
+	Cursor string `json:"cursor"`
+}
+
+// SearchContinueV2 paginates using the cursor from SearchV2.
+func (c *Files) SearchContinueV2(in *SearchContinueV2Input) (out *SearchV2Output, err error) {
+	body, err := c.call("/files/search/continue_v2", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
 // UploadInput request input.
 type UploadInput struct {
 	Path           string    `json:"path"`
@@ -371,14 +656,112 @@ type UploadOutput struct {
 	Metadata
 }
 
-// Upload a file smaller than 150MB.
+// Upload a file smaller than 150MB. When VerifyContentHash is enabled
+// a ContentHashError is returned if the uploaded file's content_hash
+// does not match the data read.
 func (c *Files) Upload(in *UploadInput) (out *UploadOutput, err error) {
-	body, _, err := c.download("/files/upload", in, in.Reader)
+	var h hash.Hash
+	r := in.Reader
+	if c.VerifyContentHash && r != nil {
+		h = NewContentHash()
+		r = io.TeeReader(r, h)
+	}
+
+	body, _, err := c.download("/files/upload", in, r)
+	if err != nil {
+		err = writeError(err)
+		return
+	}
+	defer body.Close()
+
+	if err = json.NewDecoder(body).Decode(&out); err != nil {
+		return
+	}
+
+	if h != nil {
+		err = verifyContentHash(out.PathDisplay, out.ContentHash, h)
+	}
+
+	return
+}
+
+// UploadSessionStartInput request input.
+type UploadSessionStartInput struct {
+	Close  bool      `json:"close"`
+	Reader io.Reader `json:"-"`
+}
+
+// UploadSessionStartOutput request output.
+type UploadSessionStartOutput struct {
+	SessionID string `json:"session_id"`
+}
+
+// UploadSessionStart starts an upload session, used for files larger than 150MB.
+func (c *Files) UploadSessionStart(in *UploadSessionStartInput) (out *UploadSessionStartOutput, err error) {
+	body, _, err := c.download("/files/upload_session/start", in, in.Reader)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// UploadSessionCursor specifies the session and the offset of the data uploaded so far.
+type UploadSessionCursor struct {
+	SessionID string `json:"session_id"`
+	Offset    uint64 `json:"offset"`
+}
+
+// UploadSessionAppendInput request input.
+type UploadSessionAppendInput struct {
+	Cursor UploadSessionCursor `json:"cursor"`
+	Close  bool                `json:"close"`
+	Reader io.Reader           `json:"-"`
+}
+
+// UploadSessionAppend appends more data to an upload session.
+func (c *Files) UploadSessionAppend(in *UploadSessionAppendInput) (err error) {
+	body, _, err := c.download("/files/upload_session/append_v2", in, in.Reader)
 	if err != nil {
 		return
 	}
 	defer body.Close()
 
+	return
+}
+
+// CommitInfo specifies where and how a finished upload session is saved.
+type CommitInfo struct {
+	Path           string    `json:"path"`
+	Mode           WriteMode `json:"mode"`
+	AutoRename     bool      `json:"autorename"`
+	Mute           bool      `json:"mute"`
+	ClientModified string    `json:"client_modified,omitempty"`
+}
+
+// UploadSessionFinishInput request input.
+type UploadSessionFinishInput struct {
+	Cursor UploadSessionCursor `json:"cursor"`
+	Commit CommitInfo          `json:"commit"`
+	Reader io.Reader           `json:"-"`
+}
+
+// UploadSessionFinishOutput request output.
+type UploadSessionFinishOutput struct {
+	Metadata
+}
+
+// UploadSessionFinish finishes an upload session and saves the uploaded data to the given path.
+func (c *Files) UploadSessionFinish(in *UploadSessionFinishInput) (out *UploadSessionFinishOutput, err error) {
+	body, _, err := c.download("/files/upload_session/finish", in, in.Reader)
+	if err != nil {
+		err = writeError(err)
+		return
+	}
+	defer body.Close()
+
 	err = json.NewDecoder(body).Decode(&out)
 	return
 }
@@ -388,20 +771,114 @@ type DownloadInput struct {
 	Path string `json:"path"`
 }
 
-// DownloadOutput request output.
+// DownloadOutput request output. ExportMetadata is set when the file was exported.
 type DownloadOutput struct {
-	Body   io.ReadCloser
-	Length int64
+	Body           io.ReadCloser
+	Length         int64
+	Metadata       *Metadata
+	ExportMetadata *ExportMetadata
 }
 
-// Download a file.
+// Download a file. When VerifyContentHash is enabled reading the Body
+// to EOF, and closing it, returns a ContentHashError if the data does
+// not match the file's content_hash. When ExportFallback is enabled files
+// which cannot be downloaded, such as Paper docs, are exported instead.
 func (c *Files) Download(in *DownloadInput) (out *DownloadOutput, err error) {
-	body, l, err := c.download("/files/download", in, nil)
+	var m *Metadata
+	body, l, err := c.downloadResult("/files/download", in, nil, &m)
+
+	if e, ok := err.(*Error); ok && c.ExportFallback && strings.HasPrefix(e.Summary, "unsupported_file") {
+		var export *ExportOutput
+		if export, err = c.Export(&ExportInput{Path: in.Path}); err != nil {
+			return
+		}
+
+		out = &DownloadOutput{export.Body, export.Length, export.FileMetadata, export.ExportMetadata}
+		return
+	}
+
 	if err != nil {
 		return
 	}
 
-	out = &DownloadOutput{body, l}
+	if c.VerifyContentHash && m != nil {
+		body = newVerifyReader(body, m.PathDisplay, m.ContentHash)
+	}
+
+	out = &DownloadOutput{body, l, m, nil}
+	return
+}
+
+// ExportInput request input.
+type ExportInput struct {
+	Path         string `json:"path"`
+	ExportFormat string `json:"export_format,omitempty"`
+}
+
+// ExportMetadata for an exported file.
+type ExportMetadata struct {
+	Name          string `json:"name"`
+	Size          uint64 `json:"size"`
+	ExportHash    string `json:"export_hash,omitempty"`
+	PaperRevision int64  `json:"paper_revision,omitempty"`
+}
+
+// ExportOutput request output.
+type ExportOutput struct {
+	Body           io.ReadCloser
+	Length         int64
+	ExportMetadata *ExportMetadata
+	FileMetadata   *Metadata
+}
+
+// Export a file which cannot be downloaded directly, such as a Paper
+// doc, in the format given or the one in its ExportInfo by default.
+// When VerifyContentHash is enabled the Body is verified against the
+// export_hash.
+func (c *Files) Export(in *ExportInput) (out *ExportOutput, err error) {
+	var res struct {
+		ExportMetadata *ExportMetadata `json:"export_metadata"`
+		FileMetadata   *Metadata       `json:"file_metadata"`
+	}
+
+	body, l, err := c.downloadResult("/files/export", in, nil, &res)
+	if err != nil {
+		return
+	}
+
+	if c.VerifyContentHash && res.ExportMetadata != nil {
+		body = newVerifyReader(body, in.Path, res.ExportMetadata.ExportHash)
+	}
+
+	out = &ExportOutput{body, l, res.ExportMetadata, res.FileMetadata}
+	return
+}
+
+// DownloadZipInput request input.
+type DownloadZipInput struct {
+	Path string `json:"path"`
+}
+
+// DownloadZipOutput request output.
+type DownloadZipOutput struct {
+	Body     io.ReadCloser
+	Length   int64
+	Metadata *Metadata
+}
+
+// DownloadZip a folder as a zip file. Folders over 20GB, or with over
+// 10,000 files, are rejected by the server, see WriteZip for these.
+func (c *Files) DownloadZip(in *DownloadZipInput) (out *DownloadZipOutput, err error) {
+	var res struct {
+		Metadata *Metadata `json:"metadata"`
+	}
+
+	body, l, err := c.downloadResult("/files/download_zip", in, nil, &res)
+	if err != nil {
+		return
+	}
+
+	out = &DownloadZipOutput{body, l, res.Metadata}
 	return
 }
 
@@ -431,11 +908,24 @@ const (
 	GetThumbnailSizeW1024H768 = "w1024h768"
 )
 
+// ThumbnailMode determines how the image is resized to the thumbnail size.
+type ThumbnailMode string
+
+const (
+	// GetThumbnailModeStrict scales down the image to fit within the size
+	GetThumbnailModeStrict ThumbnailMode = "strict"
+	// GetThumbnailModeBestfit scales down the image to completely cover the size
+	GetThumbnailModeBestfit ThumbnailMode = "bestfit"
+	// GetThumbnailModeFitoneBestfit scales down the image to fit one side of the size, cropping the other
+	GetThumbnailModeFitoneBestfit ThumbnailMode = "fitone_bestfit"
+)
+
 // GetThumbnailInput request input.
 type GetThumbnailInput struct {
 	Path   string          `json:"path"`
 	Format ThumbnailFormat `json:"format"`
 	Size   ThumbnailSize   `json:"size"`
+	Mode   ThumbnailMode   `json:"mode,omitempty"`
 }
 
 // GetThumbnailOutput request output.
@@ -456,6 +946,67 @@ func (c *Files) GetThumbnail(in *GetThumbnailInput) (out *GetThumbnailOutput, er
 	return
 }
 
+// MaxThumbnailBatch is the maximum number of thumbnails per GetThumbnailBatch request.
+const MaxThumbnailBatch = 25
+
+// GetThumbnailBatchInput request input.
+type GetThumbnailBatchInput struct {
+	Entries []*GetThumbnailInput `json:"entries"`
+}
+
+// GetThumbnailBatchResult is the thumbnail, or the error, for a single entry.
+type GetThumbnailBatchResult struct {
+	Metadata  *Metadata
+	Thumbnail []byte
+	Err       *Error
+}
+
+// UnmarshalJSON implementation.
+func (r *GetThumbnailBatchResult) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Tag       string          `json:".tag"`
+		Metadata  *Metadata       `json:"metadata"`
+		Thumbnail []byte          `json:"thumbnail"`
+		Failure   json.RawMessage `json:"failure"`
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	r.Metadata = v.Metadata
+	r.Thumbnail = v.Thumbnail
+
+	if v.Tag != "success" {
+		r.Err = unionError(v.Failure)
+	}
+
+	return nil
+}
+
+// GetThumbnailBatchOutput request output, with a result for each entry in order.
+type GetThumbnailBatchOutput struct {
+	Entries []*GetThumbnailBatchResult `json:"entries"`
+}
+
+// GetThumbnailBatch returns thumbnails for up to MaxThumbnailBatch files
+// in a single request. Entries which fail have their Err set.
+func (c *Files) GetThumbnailBatch(in *GetThumbnailBatchInput) (out *GetThumbnailBatchOutput, err error) {
+	if len(in.Entries) > MaxThumbnailBatch {
+		err = fmt.Errorf("dropbox: at most %d thumbnails may be requested at once", MaxThumbnailBatch)
+		return
+	}
+
+	body, err := c.callContent("/files/get_thumbnail_batch", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
 // GetPreviewInput request input.
 type GetPreviewInput struct {
 	Path string `json:"path"`
@@ -504,49 +1055,125 @@ func (c *Files) ListRevisions(in *ListRevisionsInput) (out *ListRevisionsOutput,
 	return
 }
 
-// Normalize path so people can use "/" as they expect.
-func normalizePath(s string) string {
-	if s == "/" {
-		return ""
-	}
-	return s
+// SaveURLInput request input.
+type SaveURLInput struct {
+	Path string `json:"path"`
+	URL  string `json:"url"`
 }
 
-const hashBlockSize = 4 * 1024 * 1024
+// SaveURLOutput request output. Tag is "async_job_id" while the file
+// is being saved, or "complete" with the Metadata of the saved file.
+type SaveURLOutput struct {
+	Tag        string
+	AsyncJobID string
+	Metadata   *Metadata
+}
 
-// ContentHash returns the Dropbox content_hash for a io.Reader.
-// See https://www.dropbox.com/developers/reference/content-hash
-func ContentHash(r io.Reader) (string, error) {
-	buf := make([]byte, hashBlockSize)
-	resultHash := sha256.New()
-	n, err := r.Read(buf)
-	if err != nil && err != io.EOF {
-		return "", err
+// UnmarshalJSON implementation.
+func (o *SaveURLOutput) UnmarshalJSON(b []byte) (err error) {
+	o.Tag, o.AsyncJobID, _, err = decodeAsync(b)
+	if err != nil || o.Tag != "complete" {
+		return
 	}
-	if n > 0 {
-		bufHash := sha256.Sum256(buf[:n])
-		resultHash.Write(bufHash[:])
+
+	o.Metadata, err = decodeFileMetadata(b)
+	return
+}
+
+// SaveURL saves the file at the URL to path. The file is usually saved
+// asynchronously, see SaveURLCheckJobStatus and SaveURLAndWait.
+func (c *Files) SaveURL(in *SaveURLInput) (out *SaveURLOutput, err error) {
+	body, err := c.call("/files/save_url", in)
+	if err != nil {
+		return
 	}
-	for n == hashBlockSize && err == nil {
-		n, err = r.Read(buf)
-		if err != nil && err != io.EOF {
-			return "", err
-		}
-		if n > 0 {
-			bufHash := sha256.Sum256(buf[:n])
-			resultHash.Write(bufHash[:])
-		}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// SaveURLCheckJobStatusInput request input.
+type SaveURLCheckJobStatusInput struct {
+	AsyncJobID string `json:"async_job_id"`
+}
+
+// SaveURLCheckJobStatusOutput request output. Tag is "in_progress",
+// "complete" with the Metadata of the saved file, or "failed" with Err.
+type SaveURLCheckJobStatusOutput struct {
+	Tag      string
+	Metadata *Metadata
+	Err      *Error
+}
+
+// UnmarshalJSON implementation.
+func (o *SaveURLCheckJobStatusOutput) UnmarshalJSON(b []byte) (err error) {
+	o.Tag, _, o.Err, err = decodeAsync(b)
+	if err != nil || o.Tag != "complete" {
+		return
 	}
-	return fmt.Sprintf("%x", resultHash.Sum(nil)), nil
+
+	o.Metadata, err = decodeFileMetadata(b)
+	return
+}
+
+// jobStatus implementation.
+func (o *SaveURLCheckJobStatusOutput) jobStatus() (string, *Error) {
+	return o.Tag, o.Err
+}
+
+// SaveURLCheckJobStatus checks the status of a SaveURL job.
+func (c *Files) SaveURLCheckJobStatus(in *SaveURLCheckJobStatusInput) (out *SaveURLCheckJobStatusOutput, err error) {
+	body, err := c.call("/files/save_url/check_job_status", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
 }
 
-// FileContentHash returns the Dropbox content_hash for a local file.
-// See https://www.dropbox.com/developers/reference/content-hash
-func FileContentHash(filename string) (string, error) {
-	f, err := os.Open(filename)
+// SaveURLAndWait saves the file at the URL to path, waiting for the job
+// to complete and returning the metadata of the saved file.
+func (c *Files) SaveURLAndWait(in *SaveURLInput) (*Metadata, error) {
+	out, err := c.SaveURL(in)
+	if err != nil {
+		return nil, err
+	}
+
+	if out.Tag == "complete" {
+		return out.Metadata, nil
+	}
+
+	status, err := c.waitForJob(func() (jobStatus, error) {
+		return c.SaveURLCheckJobStatus(&SaveURLCheckJobStatusInput{
+			AsyncJobID: out.AsyncJobID,
+		})
+	})
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	return status.(*SaveURLCheckJobStatusOutput).Metadata, nil
+}
+
+// decodeFileMetadata decodes file metadata inlined in a union, whose tag
+// is replaced with "file".
+func decodeFileMetadata(b []byte) (*Metadata, error) {
+	var m Metadata
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
 	}
-	defer f.Close()
-	return ContentHash(f)
+
+	m.Tag = "file"
+	return &m, nil
+}
+
+// Normalize path so people can use "/" as they expect.
+func normalizePath(s string) string {
+	if s == "/" {
+		return ""
+	}
+	return s
 }
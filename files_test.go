@@ -2,6 +2,7 @@ package dropbox
 
 import (
 	"bytes"
+	"encoding/json"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -107,6 +108,39 @@ func TestFiles_Delete(t *testing.T) {
 	assert.Equal(t, "/readme.md", out.PathLower)
 }
 
+func TestFiles_Delete_parentRev(t *testing.T) {
+	c := client()
+
+	first, err := c.Files.Upload(&UploadInput{
+		Mute:   true,
+		Mode:   WriteModeOverwrite,
+		Path:   "/delete.txt",
+		Reader: bytes.NewBufferString("first"),
+	})
+	assert.NoError(t, err, "error uploading file")
+
+	second, err := c.Files.Upload(&UploadInput{
+		Mute:   true,
+		Mode:   WriteModeOverwrite,
+		Path:   "/delete.txt",
+		Reader: bytes.NewBufferString("second"),
+	})
+	assert.NoError(t, err, "error uploading file")
+
+	_, err = c.Files.Delete(&DeleteInput{
+		Path:      "/delete.txt",
+		ParentRev: first.Rev,
+	})
+	_, ok := err.(*WriteConflictError)
+	assert.True(t, ok, "should be a conflict")
+
+	_, err = c.Files.Delete(&DeleteInput{
+		Path:      "/delete.txt",
+		ParentRev: second.Rev,
+	})
+	assert.NoError(t, err)
+}
+
 // A gray, 64 by 64 px PNG
 var grayPng = []byte{
 	0x89, 0x50, 0x4e, 0x47, 0x0d, 0x0a, 0x1a, 0x0a, 0x00, 0x00, 0x00, 0x0d, 0x49,
@@ -141,7 +175,11 @@ func TestFiles_GetThumbnail(t *testing.T) {
 		})
 		assert.NoError(t, err, "error uploading file")
 	}
-	out, err := c.Files.GetThumbnail(&GetThumbnailInput{"/gray.png", GetThumbnailFormatJPEG, GetThumbnailSizeW32H32})
+	out, err := c.Files.GetThumbnail(&GetThumbnailInput{
+		Path:   "/gray.png",
+		Format: GetThumbnailFormatJPEG,
+		Size:   GetThumbnailSizeW32H32,
+	})
 	assert.NoError(t, err)
 	if err != nil {
 		return
@@ -159,6 +197,34 @@ func TestFiles_GetThumbnail(t *testing.T) {
 	}, buf, "should have jpeg header")
 }
 
+func TestFiles_GetThumbnailBatch(t *testing.T) {
+	c := client()
+
+	_, err := c.Files.Upload(&UploadInput{
+		Mute:   true,
+		Mode:   WriteModeOverwrite,
+		Path:   "/gray.png",
+		Reader: bytes.NewBuffer(grayPng),
+	})
+	assert.NoError(t, err, "error uploading file")
+
+	out, err := c.Files.GetThumbnailBatch(&GetThumbnailBatchInput{
+		Entries: []*GetThumbnailInput{
+			{Path: "/gray.png", Format: GetThumbnailFormatPNG, Size: GetThumbnailSizeW32H32, Mode: GetThumbnailModeFitoneBestfit},
+			{Path: "/nothing.png", Format: GetThumbnailFormatPNG, Size: GetThumbnailSizeW32H32},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, out.Entries, 2)
+
+	assert.Nil(t, out.Entries[0].Err)
+	assert.Equal(t, "/gray.png", out.Entries[0].Metadata.PathLower)
+	assert.Equal(t, grayPng[:8], out.Entries[0].Thumbnail[:8], "should have png header")
+
+	assert.Error(t, out.Entries[1].Err)
+	assert.Contains(t, out.Entries[1].Err.Error(), "not_found")
+}
+
 func TestFiles_GetPreview(t *testing.T) {
 	c := client()
 
@@ -194,3 +260,171 @@ func TestFiles_ContentHash(t *testing.T) {
 
 	assert.Equal(t, "485291fa0ee50c016982abbfa943957bcd231aae0492ccbaa22c58e3997b35e0", hash)
 }
+
+func TestFiles_Upload_update(t *testing.T) {
+	c := client()
+
+	out, err := c.Files.Upload(&UploadInput{
+		Mute:   true,
+		Mode:   WriteModeOverwrite,
+		Path:   "/update.txt",
+		Reader: bytes.NewBufferString("first"),
+	})
+	assert.NoError(t, err, "error uploading file")
+
+	_, err = c.Files.Upload(&UploadInput{
+		Mute:   true,
+		Mode:   WriteModeUpdate(out.Rev),
+		Path:   "/update.txt",
+		Reader: bytes.NewBufferString("second"),
+	})
+	assert.NoError(t, err, "error updating file")
+
+	_, err = c.Files.Upload(&UploadInput{
+		Mute:   true,
+		Mode:   WriteModeUpdate(out.Rev),
+		Path:   "/update.txt",
+		Reader: bytes.NewBufferString("third"),
+	})
+
+	e, ok := err.(*WriteConflictError)
+	assert.True(t, ok, "should be a conflict")
+	assert.Equal(t, "file", e.Conflict)
+	assert.Equal(t, 409, e.Err.StatusCode)
+}
+
+func TestFiles_VerifyContentHash(t *testing.T) {
+	c := client()
+	c.VerifyContentHash = true
+
+	_, err := c.Files.Upload(&UploadInput{
+		Mute:   true,
+		Mode:   WriteModeOverwrite,
+		Path:   "/verify.txt",
+		Reader: bytes.NewBufferString("verify me"),
+	})
+	assert.NoError(t, err, "error uploading file")
+
+	out, err := c.Files.Download(&DownloadInput{"/verify.txt"})
+	assert.NoError(t, err, "error downloading")
+
+	b, err := ioutil.ReadAll(out.Body)
+	assert.NoError(t, err, "error reading remote")
+	assert.Equal(t, "verify me", string(b))
+	assert.NoError(t, out.Body.Close())
+	assert.Equal(t, "/verify.txt", out.Metadata.PathLower)
+}
+
+func TestFiles_SearchV2(t *testing.T) {
+	c := client()
+
+	out, err := c.Files.SearchV2(&SearchV2Input{
+		Query: "hello",
+		Options: &SearchOptions{
+			Path:           "/",
+			FileExtensions: []string{"txt"},
+		},
+		MatchFieldOptions: &SearchMatchFieldOptions{
+			IncludeHighlights: true,
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, out.Matches)
+	assert.NotEmpty(t, out.Matches[0].HighlightSpans)
+}
+
+func TestFiles_SearchV2Iterator(t *testing.T) {
+	c := client()
+
+	it := c.Files.SearchV2Iterator(&SearchV2Input{
+		Query: "hello",
+		Options: &SearchOptions{
+			MaxResults: 1,
+		},
+	})
+
+	var n int
+	for it.Next() {
+		assert.NotNil(t, it.Match().Metadata.Metadata)
+		n++
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 2, n)
+}
+
+func TestMetadata_Downloadable(t *testing.T) {
+	var file, folder, paper Metadata
+
+	assert.NoError(t, json.Unmarshal([]byte(`{".tag":"file","name":"a.txt"}`), &file))
+	assert.NoError(t, json.Unmarshal([]byte(`{".tag":"folder","name":"a"}`), &folder))
+	assert.NoError(t, json.Unmarshal([]byte(`{".tag":"file","name":"a.paper","is_downloadable":false}`), &paper))
+
+	assert.True(t, file.Downloadable())
+	assert.False(t, folder.Downloadable())
+	assert.False(t, paper.Downloadable())
+}
+
+func TestFiles_Export(t *testing.T) {
+	c := client()
+
+	meta, err := c.Files.GetMetadata(&GetMetadataInput{
+		Path: "/notes.paper",
+	})
+	assert.NoError(t, err)
+	assert.False(t, meta.Downloadable())
+	assert.NotNil(t, meta.ExportInfo)
+
+	out, err := c.Files.Export(&ExportInput{
+		Path:         "/notes.paper",
+		ExportFormat: "markdown",
+	})
+	assert.NoError(t, err)
+	defer out.Body.Close()
+
+	assert.Equal(t, "/notes.paper", out.FileMetadata.PathLower)
+	assert.NotEmpty(t, out.ExportMetadata.Name)
+}
+
+func TestFiles_Download_exportFallback(t *testing.T) {
+	c := client()
+	c.ExportFallback = true
+
+	out, err := c.Files.Download(&DownloadInput{"/notes.paper"})
+	assert.NoError(t, err)
+	defer out.Body.Close()
+
+	assert.NotNil(t, out.ExportMetadata)
+}
+
+func TestFiles_SaveURLAndWait(t *testing.T) {
+	c := client()
+
+	out, err := c.Files.SaveURLAndWait(&SaveURLInput{
+		Path: "/milky-way.jpg",
+		URL:  "https://www.dropbox.com/static/images/developers/milky-way-nasa.jpg",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/milky-way.jpg", out.PathLower)
+	assert.Equal(t, "485291fa0ee50c016982abbfa943957bcd231aae0492ccbaa22c58e3997b35e0", out.ContentHash)
+}
+
+func TestFiles_CopyBetween(t *testing.T) {
+	c := client()
+
+	_, err := c.Files.Upload(&UploadInput{
+		Mute:   true,
+		Mode:   WriteModeOverwrite,
+		Path:   "/reference.txt",
+		Reader: bytes.NewBufferString("copy me"),
+	})
+	assert.NoError(t, err, "error uploading file")
+
+	c.Files.Delete(&DeleteInput{Path: "/reference-copy.txt"})
+
+	out, err := CopyBetween(c.Files, "/reference.txt", c.Files, "/reference-copy.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "/reference-copy.txt", out.PathLower)
+}
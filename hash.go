@@ -0,0 +1,166 @@
+package dropbox
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"os"
+)
+
+// hashBlockSize is the size of the blocks hashed individually.
+const hashBlockSize = 4 * 1024 * 1024
+
+// contentHash implements hash.Hash for the Dropbox content_hash.
+type contentHash struct {
+	blocks []byte    // digests of the completed blocks
+	block  hash.Hash // digest of the current block
+	n      int       // bytes written to the current block
+}
+
+// NewContentHash returns a hash.Hash computing the Dropbox content_hash,
+// the SHA-256 of the concatenated SHA-256 digests of each 4MB block.
+// See https://www.dropbox.com/developers/reference/content-hash
+func NewContentHash() hash.Hash {
+	return &contentHash{block: sha256.New()}
+}
+
+// Write implements io.Writer.
+func (h *contentHash) Write(p []byte) (int, error) {
+	written := len(p)
+
+	for len(p) > 0 {
+		n := hashBlockSize - h.n
+		if n > len(p) {
+			n = len(p)
+		}
+
+		h.block.Write(p[:n])
+		h.n += n
+		p = p[n:]
+
+		if h.n == hashBlockSize {
+			h.blocks = h.block.Sum(h.blocks)
+			h.block.Reset()
+			h.n = 0
+		}
+	}
+
+	return written, nil
+}
+
+// Sum appends the content hash to b without changing the underlying state.
+func (h *contentHash) Sum(b []byte) []byte {
+	blocks := h.blocks
+	if h.n > 0 {
+		blocks = h.block.Sum(blocks[:len(blocks):len(blocks)])
+	}
+
+	sum := sha256.Sum256(blocks)
+	return append(b, sum[:]...)
+}
+
+// Reset implementation.
+func (h *contentHash) Reset() {
+	h.blocks = h.blocks[:0]
+	h.block.Reset()
+	h.n = 0
+}
+
+// Size implementation.
+func (h *contentHash) Size() int {
+	return sha256.Size
+}
+
+// BlockSize implementation.
+func (h *contentHash) BlockSize() int {
+	return sha256.BlockSize
+}
+
+// ContentHash returns the Dropbox content_hash for a io.Reader.
+// See https://www.dropbox.com/developers/reference/content-hash
+func ContentHash(r io.Reader) (string, error) {
+	h := NewContentHash()
+
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// FileContentHash returns the Dropbox content_hash for a local file.
+// See https://www.dropbox.com/developers/reference/content-hash
+func FileContentHash(filename string) (string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return ContentHash(f)
+}
+
+// verifyContentHash returns a ContentHashError if h does not sum to expected.
+func verifyContentHash(path, expected string, h hash.Hash) error {
+	actual := hex.EncodeToString(h.Sum(nil))
+	if actual == expected {
+		return nil
+	}
+
+	return &ContentHashError{
+		Path:     path,
+		Expected: expected,
+		Actual:   actual,
+	}
+}
+
+// verifyReader hashes the data read, verifying it at EOF.
+type verifyReader struct {
+	io.ReadCloser
+	hash     hash.Hash
+	path     string
+	expected string
+	err      error
+}
+
+// newVerifyReader returns a reader verifying r against the expected
+// content hash. No verification is performed if expected is empty.
+func newVerifyReader(r io.ReadCloser, path, expected string) io.ReadCloser {
+	if expected == "" {
+		return r
+	}
+
+	return &verifyReader{
+		ReadCloser: r,
+		hash:       NewContentHash(),
+		path:       path,
+		expected:   expected,
+	}
+}
+
+// Read implementation, returning a ContentHashError instead of io.EOF on mismatch.
+func (r *verifyReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	n, err := r.ReadCloser.Read(p)
+	r.hash.Write(p[:n])
+
+	if err == io.EOF {
+		if r.err = verifyContentHash(r.path, r.expected, r.hash); r.err != nil {
+			return n, r.err
+		}
+	}
+
+	return n, err
+}
+
+// Close implementation, returning the mismatch if one was detected.
+func (r *verifyReader) Close() error {
+	err := r.ReadCloser.Close()
+	if r.err != nil {
+		return r.err
+	}
+	return err
+}
@@ -0,0 +1,66 @@
+package dropbox
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// expectedContentHash computes the content hash of data in whole blocks.
+func expectedContentHash(data []byte) string {
+	var blocks []byte
+	for len(data) > 0 {
+		n := hashBlockSize
+		if n > len(data) {
+			n = len(data)
+		}
+		sum := sha256.Sum256(data[:n])
+		blocks = append(blocks, sum[:]...)
+		data = data[n:]
+	}
+	sum := sha256.Sum256(blocks)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestNewContentHash(t *testing.T) {
+	data := bytes.Repeat([]byte("dropbox"), hashBlockSize/3)
+
+	h := NewContentHash()
+	_, err := io.CopyBuffer(h, bytes.NewReader(data), make([]byte, 1000))
+	assert.NoError(t, err)
+	assert.Equal(t, expectedContentHash(data), hex.EncodeToString(h.Sum(nil)))
+	assert.Equal(t, h.Sum(nil), h.Sum(nil), "sum should not change state")
+
+	h.Reset()
+	assert.Equal(t, expectedContentHash(nil), hex.EncodeToString(h.Sum(nil)))
+}
+
+func TestContentHash_shortReads(t *testing.T) {
+	data := bytes.Repeat([]byte("dropbox"), hashBlockSize/5)
+
+	hash, err := ContentHash(iotest.HalfReader(bytes.NewReader(data)))
+	assert.NoError(t, err)
+	assert.Equal(t, expectedContentHash(data), hash)
+}
+
+func TestVerifyReader(t *testing.T) {
+	data := []byte("hello world")
+	hash, _ := ContentHash(bytes.NewReader(data))
+
+	r := newVerifyReader(ioutil.NopCloser(bytes.NewReader(data)), "/hello.txt", hash)
+	b, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, data, b)
+	assert.NoError(t, r.Close())
+
+	r = newVerifyReader(ioutil.NopCloser(bytes.NewReader(data[1:])), "/hello.txt", hash)
+	_, err = ioutil.ReadAll(r)
+	assert.IsType(t, &ContentHashError{}, err)
+	assert.Equal(t, err, r.Close())
+}
@@ -0,0 +1,157 @@
+package dropbox
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// LockFileArg specifies a file to lock, unlock or query.
+type LockFileArg struct {
+	Path string `json:"path"`
+}
+
+// FileLock is the lock held on a file.
+type FileLock struct {
+	Content struct {
+		Tag                 string    `json:".tag"`
+		Created             time.Time `json:"created"`
+		LockHolderAccountID string    `json:"lock_holder_account_id"`
+		LockHolderTeamID    string    `json:"lock_holder_team_id,omitempty"`
+	} `json:"content"`
+}
+
+// LockFileResult is the lock, or the error, for a single entry.
+type LockFileResult struct {
+	Metadata *Metadata
+	Lock     *FileLock
+	Err      *Error
+}
+
+// UnmarshalJSON implementation.
+func (r *LockFileResult) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Tag      string          `json:".tag"`
+		Metadata *Metadata       `json:"metadata"`
+		Lock     *FileLock       `json:"lock"`
+		Failure  json.RawMessage `json:"failure"`
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	r.Metadata = v.Metadata
+	r.Lock = v.Lock
+
+	if v.Tag != "success" {
+		r.Err = unionError(v.Failure)
+	}
+
+	return nil
+}
+
+// LockFileBatchOutput request output, with a result for each entry in order.
+type LockFileBatchOutput struct {
+	Entries []*LockFileResult `json:"entries"`
+}
+
+// LockFileBatchInput request input.
+type LockFileBatchInput struct {
+	Entries []*LockFileArg `json:"entries"`
+}
+
+// LockFileBatch locks files so that only the lock holder may edit them.
+// Entries which fail have their Err set.
+func (c *Files) LockFileBatch(in *LockFileBatchInput) (out *LockFileBatchOutput, err error) {
+	body, err := c.call("/files/lock_file_batch", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// UnlockFileBatchInput request input.
+type UnlockFileBatchInput struct {
+	Entries []*LockFileArg `json:"entries"`
+}
+
+// UnlockFileBatch unlocks files. Entries which fail have their Err set.
+func (c *Files) UnlockFileBatch(in *UnlockFileBatchInput) (out *LockFileBatchOutput, err error) {
+	body, err := c.call("/files/unlock_file_batch", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// GetFileLockBatchInput request input.
+type GetFileLockBatchInput struct {
+	Entries []*LockFileArg `json:"entries"`
+}
+
+// GetFileLockBatch returns the locks held on files. Entries which fail have their Err set.
+func (c *Files) GetFileLockBatch(in *GetFileLockBatchInput) (out *LockFileBatchOutput, err error) {
+	body, err := c.call("/files/get_file_lock_batch", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// errEmptyLockBatch is returned when a lock batch has no results.
+var errEmptyLockBatch = errors.New("dropbox: empty lock batch result")
+
+// WithLock locks the file at path while calling fn, releasing the lock
+// even if fn fails or panics. The error from fn takes precedence over
+// unlocking.
+func (c *Files) WithLock(path string, fn func() error) (err error) {
+	out, err := c.LockFileBatch(&LockFileBatchInput{
+		Entries: []*LockFileArg{{Path: path}},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := lockResult(out); err != nil {
+		return err
+	}
+
+	defer func() {
+		out, uerr := c.UnlockFileBatch(&UnlockFileBatchInput{
+			Entries: []*LockFileArg{{Path: path}},
+		})
+
+		if uerr == nil {
+			uerr = lockResult(out)
+		}
+
+		if err == nil {
+			err = uerr
+		}
+	}()
+
+	return fn()
+}
+
+// lockResult returns the error of the single entry of a batch.
+func lockResult(out *LockFileBatchOutput) error {
+	if len(out.Entries) == 0 {
+		return errEmptyLockBatch
+	}
+
+	if e := out.Entries[0].Err; e != nil {
+		return e
+	}
+
+	return nil
+}
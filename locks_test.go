@@ -0,0 +1,45 @@
+package dropbox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFiles_WithLock(t *testing.T) {
+	c := client()
+
+	err := c.Files.WithLock("/hello.txt", func() error {
+		out, err := c.Files.GetFileLockBatch(&GetFileLockBatchInput{
+			Entries: []*LockFileArg{{Path: "/hello.txt"}},
+		})
+		assert.NoError(t, err)
+		assert.Nil(t, out.Entries[0].Err)
+		assert.True(t, out.Entries[0].Metadata.FileLockInfo.IsLockholder)
+		return errors.New("boom")
+	})
+	assert.EqualError(t, err, "boom")
+
+	out, err := c.Files.GetMetadata(&GetMetadataInput{
+		Path: "/hello.txt",
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, out.FileLockInfo, "lock should be released")
+}
+
+func TestFiles_WithLock_panic(t *testing.T) {
+	c := client()
+
+	assert.Panics(t, func() {
+		c.Files.WithLock("/hello.txt", func() error {
+			panic("boom")
+		})
+	})
+
+	out, err := c.Files.GetMetadata(&GetMetadataInput{
+		Path: "/hello.txt",
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, out.FileLockInfo, "lock should be released")
+}
@@ -0,0 +1,100 @@
+package dropbox
+
+// SearchIterator iterates over all of the matches of a SearchV2 query,
+// requesting further pages as required.
+type SearchIterator struct {
+	files *Files
+	in    *SearchV2Input
+	out   *SearchV2Output
+	match *SearchMatchV2
+	tag   string
+	err   error
+}
+
+// SearchV2Iterator returns an iterator over all of the matches for the query.
+func (c *Files) SearchV2Iterator(in *SearchV2Input) *SearchIterator {
+	return &SearchIterator{
+		files: c,
+		in:    in,
+	}
+}
+
+// WithTag filters the matches to those tagged with tag, see FilterByTag.
+func (it *SearchIterator) WithTag(tag string) *SearchIterator {
+	it.tag = tag
+	return it
+}
+
+// Next advances to the next match, returning false when there are no
+// more matches or an error occurred.
+func (it *SearchIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.out == nil || len(it.out.Matches) == 0 {
+		if it.out != nil && !it.out.HasMore {
+			it.match = nil
+			return false
+		}
+
+		if it.out == nil {
+			it.out, it.err = it.files.SearchV2(it.in)
+		} else {
+			it.out, it.err = it.files.SearchContinueV2(&SearchContinueV2Input{
+				Cursor: it.out.Cursor,
+			})
+		}
+
+		if it.err == nil && it.tag != "" {
+			it.err = it.filter()
+		}
+
+		if it.err != nil {
+			it.match = nil
+			return false
+		}
+	}
+
+	it.match = it.out.Matches[0]
+	it.out.Matches = it.out.Matches[1:]
+	return true
+}
+
+// Match returns the current match.
+func (it *SearchIterator) Match() *SearchMatchV2 {
+	return it.match
+}
+
+// Err returns the error which stopped iteration, if any.
+func (it *SearchIterator) Err() error {
+	return it.err
+}
+
+// filter the current page to the matches with the tag.
+func (it *SearchIterator) filter() error {
+	var entries []*Metadata
+	for _, m := range it.out.Matches {
+		entries = append(entries, m.Metadata.Metadata)
+	}
+
+	tagged, err := it.files.FilterByTag(entries, it.tag)
+	if err != nil {
+		return err
+	}
+
+	keep := make(map[*Metadata]bool)
+	for _, m := range tagged {
+		keep[m] = true
+	}
+
+	matches := it.out.Matches[:0]
+	for _, m := range it.out.Matches {
+		if keep[m.Metadata.Metadata] {
+			matches = append(matches, m)
+		}
+	}
+
+	it.out.Matches = matches
+	return nil
+}
@@ -2,6 +2,8 @@ package dropbox
 
 import (
 	"encoding/json"
+	"io"
+	"strings"
 	"time"
 )
 
@@ -21,17 +23,106 @@ func NewSharing(config *Config) *Sharing {
 
 // CreateSharedLinkInput request input.
 type CreateSharedLinkInput struct {
-	Path string `json:"path"`
+	Path     string              `json:"path"`
+	Settings *SharedLinkSettings `json:"settings,omitempty"`
 }
 
 // CreateSharedLinkOutput request output.
 type CreateSharedLinkOutput struct {
+	SharedLinkMetadata
+}
+
+// SharedLinkMetadata describes a shared link to a file or folder.
+type SharedLinkMetadata struct {
+	Tag             string `json:".tag"`
 	URL             string `json:"url"`
+	ID              string `json:"id,omitempty"`
+	Name            string `json:"name"`
 	Path            string `json:"path"`
+	PathLower       string `json:"path_lower,omitempty"`
 	VisibilityModel struct {
 		Tag VisibilityType `json:".tag"`
 	} `json:"visibility"`
-	Expires time.Time `json:"expires,omitempty"`
+	Expires         time.Time       `json:"expires,omitempty"`
+	LinkPermissions LinkPermissions `json:"link_permissions"`
+	Rev             string          `json:"rev,omitempty"`
+	Size            uint64          `json:"size,omitempty"`
+	ClientModified  time.Time       `json:"client_modified,omitempty"`
+	ServerModified  time.Time       `json:"server_modified,omitempty"`
+}
+
+// LinkPermissions describes what the current user may do with a shared link.
+type LinkPermissions struct {
+	ResolvedVisibility *struct {
+		Tag VisibilityType `json:".tag"`
+	} `json:"resolved_visibility,omitempty"`
+	RequestedVisibility *struct {
+		Tag VisibilityType `json:".tag"`
+	} `json:"requested_visibility,omitempty"`
+	EffectiveAudience *struct {
+		Tag LinkAudience `json:".tag"`
+	} `json:"effective_audience,omitempty"`
+	LinkAccessLevel *struct {
+		Tag LinkAccessLevel `json:".tag"`
+	} `json:"link_access_level,omitempty"`
+	CanRevoke           bool `json:"can_revoke"`
+	RevokeFailureReason *struct {
+		Tag string `json:".tag"`
+	} `json:"revoke_failure_reason,omitempty"`
+	AllowDownload       bool `json:"allow_download"`
+	CanSetExpiry        bool `json:"can_set_expiry"`
+	CanRemoveExpiry     bool `json:"can_remove_expiry"`
+	CanSetPassword      bool `json:"can_set_password"`
+	CanRemovePassword   bool `json:"can_remove_password"`
+	CanAllowDownload    bool `json:"can_allow_download"`
+	CanDisallowDownload bool `json:"can_disallow_download"`
+}
+
+// LinkAudience determines who a shared link is intended for.
+type LinkAudience string
+
+// Link audiences supported.
+const (
+	LinkAudiencePublic   LinkAudience = "public"
+	LinkAudienceTeam     LinkAudience = "team"
+	LinkAudienceNoOne    LinkAudience = "no_one"
+	LinkAudienceMembers  LinkAudience = "members"
+	LinkAudiencePassword LinkAudience = "password"
+)
+
+// LinkAccessLevel determines what a shared link grants access to.
+type LinkAccessLevel string
+
+// Link access levels supported.
+const (
+	LinkAccessLevelViewer LinkAccessLevel = "viewer"
+	LinkAccessLevelEditor LinkAccessLevel = "editor"
+)
+
+// SharedLinkSettings for creating or modifying a shared link. Zero values
+// are omitted, leaving the account's defaults in place.
+type SharedLinkSettings struct {
+	RequestedVisibility VisibilityType  `json:"requested_visibility,omitempty"`
+	LinkPassword        string          `json:"link_password,omitempty"`
+	Expires             time.Time       `json:"-"`
+	Audience            LinkAudience    `json:"audience,omitempty"`
+	Access              LinkAccessLevel `json:"access,omitempty"`
+	AllowDownload       *bool           `json:"allow_download,omitempty"`
+}
+
+// MarshalJSON implementation.
+func (s SharedLinkSettings) MarshalJSON() ([]byte, error) {
+	type settings SharedLinkSettings
+
+	var expires string
+	if !s.Expires.IsZero() {
+		expires = timestamp(s.Expires)
+	}
+
+	return json.Marshal(struct {
+		settings
+		Expires string `json:"expires,omitempty"`
+	}{settings(s), expires})
 }
 
 // VisibilityType determines who can access the link.
@@ -58,24 +149,90 @@ func (c *Sharing) CreateSharedLink(in *CreateSharedLinkInput) (out *CreateShared
 	return
 }
 
+// ModifySharedLinkSettingsInput request input.
+type ModifySharedLinkSettingsInput struct {
+	URL              string             `json:"url"`
+	Settings         SharedLinkSettings `json:"settings"`
+	RemoveExpiration bool               `json:"remove_expiration"`
+}
+
+// ModifySharedLinkSettings changes the settings of a shared link.
+func (c *Sharing) ModifySharedLinkSettings(in *ModifySharedLinkSettingsInput) (out *SharedLinkMetadata, err error) {
+	body, err := c.call("/sharing/modify_shared_link_settings", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// GetSharedLinkMetadataInput request input.
+type GetSharedLinkMetadataInput struct {
+	URL          string `json:"url"`
+	Path         string `json:"path,omitempty"`
+	LinkPassword string `json:"link_password,omitempty"`
+}
+
+// GetSharedLinkMetadata returns the metadata of a shared link, or of the
+// file at Path relative to a shared folder link.
+func (c *Sharing) GetSharedLinkMetadata(in *GetSharedLinkMetadataInput) (out *SharedLinkMetadata, err error) {
+	body, err := c.call("/sharing/get_shared_link_metadata", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// GetSharedLinkFileInput request input.
+type GetSharedLinkFileInput struct {
+	URL          string `json:"url"`
+	Path         string `json:"path,omitempty"`
+	LinkPassword string `json:"link_password,omitempty"`
+}
+
+// GetSharedLinkFileOutput request output.
+type GetSharedLinkFileOutput struct {
+	Body     io.ReadCloser
+	Length   int64
+	Metadata *SharedLinkMetadata
+}
+
+// GetSharedLinkFile downloads the file of a shared link, or the file at
+// Path relative to a shared folder link. Folders are returned as a zip.
+func (c *Sharing) GetSharedLinkFile(in *GetSharedLinkFileInput) (out *GetSharedLinkFileOutput, err error) {
+	var m *SharedLinkMetadata
+
+	body, l, err := c.downloadResult("/sharing/get_shared_link_file", in, nil, &m)
+	if err != nil {
+		return
+	}
+
+	out = &GetSharedLinkFileOutput{body, l, m}
+	return
+}
+
 // ListShareLinksInput request input.
 type ListShareLinksInput struct {
-	Path string `json:"path"`
+	Path       string `json:"path,omitempty"`
+	Cursor     string `json:"cursor,omitempty"`
+	DirectOnly bool   `json:"direct_only,omitempty"`
 }
 
 // SharedLinkOutput request output.
 type SharedLinkOutput struct {
-	URL             string `json:"url"`
-	Path            string `json:"path"`
-	VisibilityModel struct {
-		Tag VisibilityType `json:".tag"`
-	} `json:"visibility"`
-	Expires time.Time `json:"expires,omitempty"`
+	SharedLinkMetadata
 }
 
 // ListShareLinksOutput request output.
 type ListShareLinksOutput struct {
-	Links []SharedLinkOutput `json:"links"`
+	Links   []SharedLinkOutput `json:"links"`
+	HasMore bool               `json:"has_more"`
+	Cursor  string             `json:"cursor"`
 }
 
 // ListSharedLinks gets shared links of input.
@@ -91,6 +248,85 @@ func (c *Sharing) ListSharedLinks(in *ListShareLinksInput) (out *ListShareLinksO
 	return
 }
 
+// RevokeSharedLinkInput request input.
+type RevokeSharedLinkInput struct {
+	URL string `json:"url"`
+}
+
+// RevokeSharedLink revokes a shared link.
+func (c *Sharing) RevokeSharedLink(in *RevokeSharedLinkInput) (err error) {
+	body, err := c.call("/sharing/revoke_shared_link", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	return
+}
+
+// RevokeSharedLinksInput selects the links revoked by RevokeSharedLinks.
+// When both fields are set only links matching both are revoked.
+type RevokeSharedLinksInput struct {
+	// Path selects links to the path or anything beneath it.
+	Path string
+
+	// ExpiresBefore selects links expiring before the given time, links
+	// without an expiry are never selected.
+	ExpiresBefore time.Time
+}
+
+// RevokeSharedLinks revokes the current user's shared links matching in,
+// returning the links revoked.
+func (c *Sharing) RevokeSharedLinks(in *RevokeSharedLinksInput) (revoked []SharedLinkOutput, err error) {
+	var links []SharedLinkOutput
+
+	list := &ListShareLinksInput{}
+	for {
+		out, err := c.ListSharedLinks(list)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, l := range out.Links {
+			if in.matches(&l.SharedLinkMetadata) {
+				links = append(links, l)
+			}
+		}
+
+		if !out.HasMore {
+			break
+		}
+		list.Cursor = out.Cursor
+	}
+
+	for _, l := range links {
+		if err := c.RevokeSharedLink(&RevokeSharedLinkInput{URL: l.URL}); err != nil {
+			return revoked, err
+		}
+		revoked = append(revoked, l)
+	}
+
+	return revoked, nil
+}
+
+// matches returns true if the link is selected.
+func (in *RevokeSharedLinksInput) matches(l *SharedLinkMetadata) bool {
+	if in.Path != "" {
+		prefix := strings.TrimSuffix(strings.ToLower(in.Path), "/")
+		if l.PathLower != prefix && !strings.HasPrefix(l.PathLower, prefix+"/") {
+			return false
+		}
+	}
+
+	if !in.ExpiresBefore.IsZero() {
+		if l.Expires.IsZero() || !l.Expires.Before(in.ExpiresBefore) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // ListSharedFolderInput request input.
 type ListSharedFolderInput struct {
 	Limit   uint64         `json:"limit"`
@@ -98,8 +334,35 @@ type ListSharedFolderInput struct {
 }
 
 // FolderAction defines actions that may be taken on shared folders.
-type FolderAction struct {
-	ChangeOptions string
+type FolderAction string
+
+// Folder actions supported.
+const (
+	FolderActionChangeOptions         FolderAction = "change_options"
+	FolderActionDisableViewerInfo     FolderAction = "disable_viewer_info"
+	FolderActionEditContents          FolderAction = "edit_contents"
+	FolderActionEnableViewerInfo      FolderAction = "enable_viewer_info"
+	FolderActionInviteEditor          FolderAction = "invite_editor"
+	FolderActionInviteViewer          FolderAction = "invite_viewer"
+	FolderActionInviteViewerNoComment FolderAction = "invite_viewer_no_comment"
+	FolderActionRelinquishMembership  FolderAction = "relinquish_membership"
+	FolderActionUnmount               FolderAction = "unmount"
+	FolderActionUnshare               FolderAction = "unshare"
+	FolderActionLeaveACopy            FolderAction = "leave_a_copy"
+	FolderActionCreateLink            FolderAction = "create_link"
+	FolderActionSetAccessInheritance  FolderAction = "set_access_inheritance"
+)
+
+// FolderPermission determines whether an action may be taken on a shared
+// folder, with the Reason when it may not.
+type FolderPermission struct {
+	Action struct {
+		Tag FolderAction `json:".tag"`
+	} `json:"action"`
+	Allow  bool `json:"allow"`
+	Reason *struct {
+		Tag string `json:".tag"`
+	} `json:"reason,omitempty"`
 }
 
 // ListSharedFolderOutput lists metadata about shared folders with a cursor to retrieve the next page.
@@ -137,6 +400,31 @@ func (c *Sharing) ListSharedFoldersContinue(in *ListSharedFolderContinueInput) (
 	return
 }
 
+// ListMountableFolders returns the shared folders the current user can
+// mount or has mounted, taking the same input as ListSharedFolders.
+func (c *Sharing) ListMountableFolders(in *ListSharedFolderInput) (out *ListSharedFolderOutput, err error) {
+	body, err := c.call("/sharing/list_mountable_folders", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// ListMountableFoldersContinue paginates using the cursor from ListMountableFolders.
+func (c *Sharing) ListMountableFoldersContinue(in *ListSharedFolderContinueInput) (out *ListSharedFolderOutput, err error) {
+	body, err := c.call("/sharing/list_mountable_folders/continue", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
 // SharedFolderMetadata includes basic information about the shared folder.
 type SharedFolderMetadata struct {
 	AccessType struct {
@@ -151,9 +439,50 @@ type SharedFolderMetadata struct {
 		ID   string `json:"id"`
 		Name string `json:"name"`
 	} `json:"owner_team"`
-	ParentSharedFolderID string   `json:"parent_shared_folder_id"`
-	PathLower            string   `json:"path_lower"`
-	Permissions          []string `json:"permissions"`
+	ParentSharedFolderID string                     `json:"parent_shared_folder_id"`
+	PathLower            string                     `json:"path_lower"`
+	Permissions          []*FolderPermission        `json:"permissions,omitempty"`
+	LinkMetadata         *SharedContentLinkMetadata `json:"link_metadata,omitempty"`
+	AccessInheritance    struct {
+		Tag AccessInheritance `json:".tag"`
+	} `json:"access_inheritance"`
+}
+
+// AccessInheritance determines whether a folder inherits its parent's members.
+type AccessInheritance string
+
+// AccessInheritance types supported.
+const (
+	AccessInheritanceInherit   AccessInheritance = "inherit"
+	AccessInheritanceNoInherit AccessInheritance = "no_inherit"
+)
+
+// SharedContentLinkMetadata describes the shared link of shared content.
+type SharedContentLinkMetadata struct {
+	URL             string `json:"url"`
+	AudienceOptions []struct {
+		Tag LinkAudience `json:".tag"`
+	} `json:"audience_options"`
+	CurrentAudience struct {
+		Tag LinkAudience `json:".tag"`
+	} `json:"current_audience"`
+	LinkPermissions   []*LinkPermission `json:"link_permissions"`
+	PasswordProtected bool              `json:"password_protected"`
+	AccessLevel       *struct {
+		Tag LinkAccessLevel `json:".tag"`
+	} `json:"access_level,omitempty"`
+	Expiry time.Time `json:"expiry,omitempty"`
+}
+
+// LinkPermission determines whether an action may be taken on a link.
+type LinkPermission struct {
+	Action struct {
+		Tag string `json:".tag"`
+	} `json:"action"`
+	Allow  bool `json:"allow"`
+	Reason *struct {
+		Tag string `json:".tag"`
+	} `json:"reason,omitempty"`
 }
 
 // FolderPolicy enumerates the policies governing this shared folder.
@@ -209,3 +538,866 @@ const (
 	MemberPolicyTeam   MemberPolicy = "team"
 	MemberPolicyAnyone              = "anyone"
 )
+
+// MemberSelector identifies a member by Dropbox ID or email address.
+type MemberSelector struct {
+	Tag       string `json:".tag"`
+	DropboxID string `json:"dropbox_id,omitempty"`
+	Email     string `json:"email,omitempty"`
+}
+
+// MemberDropboxID selects a member by account, team member or group ID.
+func MemberDropboxID(id string) *MemberSelector {
+	return &MemberSelector{Tag: "dropbox_id", DropboxID: id}
+}
+
+// MemberEmail selects a member by email address.
+func MemberEmail(email string) *MemberSelector {
+	return &MemberSelector{Tag: "email", Email: email}
+}
+
+// AsyncJobOutput request output. Tag is "async_job_id" while the job
+// runs, or "complete" if it finished immediately.
+type AsyncJobOutput struct {
+	Tag        string `json:".tag"`
+	AsyncJobID string `json:"async_job_id,omitempty"`
+}
+
+// JobStatusOutput request output. Tag is "in_progress", "complete", or
+// "failed" with Err.
+type JobStatusOutput struct {
+	Tag string
+	Err *Error
+}
+
+// UnmarshalJSON implementation.
+func (o *JobStatusOutput) UnmarshalJSON(b []byte) (err error) {
+	o.Tag, _, o.Err, err = decodeAsync(b)
+	return
+}
+
+// jobStatus implementation.
+func (o *JobStatusOutput) jobStatus() (string, *Error) {
+	return o.Tag, o.Err
+}
+
+// CheckJobStatusInput request input.
+type CheckJobStatusInput struct {
+	AsyncJobID string `json:"async_job_id"`
+}
+
+// CheckJobStatus checks the status of an asynchronous job such as UnshareFolder.
+func (c *Sharing) CheckJobStatus(in *CheckJobStatusInput) (out *JobStatusOutput, err error) {
+	body, err := c.call("/sharing/check_job_status", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// WaitForJob waits for an asynchronous job to complete, see CheckJobStatus.
+func (c *Sharing) WaitForJob(out *AsyncJobOutput) error {
+	if out.Tag == "complete" {
+		return nil
+	}
+
+	_, err := c.waitForJob(func() (jobStatus, error) {
+		return c.CheckJobStatus(&CheckJobStatusInput{AsyncJobID: out.AsyncJobID})
+	})
+
+	return err
+}
+
+// ShareFolderInput request input.
+type ShareFolderInput struct {
+	Path             string           `json:"path"`
+	ACLUpdatePolicy  ACLUpdatePolicy  `json:"acl_update_policy,omitempty"`
+	ForceAsync       bool             `json:"force_async,omitempty"`
+	MemberPolicy     MemberPolicy     `json:"member_policy,omitempty"`
+	SharedLinkPolicy SharedLinkPolicy `json:"shared_link_policy,omitempty"`
+}
+
+// ShareFolderOutput request output. Tag is "async_job_id" while the
+// folder is being shared, or "complete" with its Metadata.
+type ShareFolderOutput struct {
+	Tag        string
+	AsyncJobID string
+	Metadata   *SharedFolderMetadata
+}
+
+// UnmarshalJSON implementation.
+func (o *ShareFolderOutput) UnmarshalJSON(b []byte) (err error) {
+	o.Tag, o.AsyncJobID, _, err = decodeAsync(b)
+	if err != nil || o.Tag != "complete" {
+		return
+	}
+
+	err = json.Unmarshal(b, &o.Metadata)
+	return
+}
+
+// ShareFolder shares a folder, which may complete asynchronously, see
+// CheckShareJobStatus and ShareFolderAndWait.
+func (c *Sharing) ShareFolder(in *ShareFolderInput) (out *ShareFolderOutput, err error) {
+	body, err := c.call("/sharing/share_folder", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// CheckShareJobStatusInput request input.
+type CheckShareJobStatusInput struct {
+	AsyncJobID string `json:"async_job_id"`
+}
+
+// CheckShareJobStatusOutput request output. Tag is "in_progress",
+// "complete" with the shared folder's Metadata, or "failed" with Err.
+type CheckShareJobStatusOutput struct {
+	Tag      string
+	Metadata *SharedFolderMetadata
+	Err      *Error
+}
+
+// UnmarshalJSON implementation.
+func (o *CheckShareJobStatusOutput) UnmarshalJSON(b []byte) (err error) {
+	o.Tag, _, o.Err, err = decodeAsync(b)
+	if err != nil || o.Tag != "complete" {
+		return
+	}
+
+	err = json.Unmarshal(b, &o.Metadata)
+	return
+}
+
+// jobStatus implementation.
+func (o *CheckShareJobStatusOutput) jobStatus() (string, *Error) {
+	return o.Tag, o.Err
+}
+
+// CheckShareJobStatus checks the status of a ShareFolder job.
+func (c *Sharing) CheckShareJobStatus(in *CheckShareJobStatusInput) (out *CheckShareJobStatusOutput, err error) {
+	body, err := c.call("/sharing/check_share_job_status", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// ShareFolderAndWait shares a folder, waiting for the job to complete
+// and returning the shared folder's metadata.
+func (c *Sharing) ShareFolderAndWait(in *ShareFolderInput) (*SharedFolderMetadata, error) {
+	out, err := c.ShareFolder(in)
+	if err != nil {
+		return nil, err
+	}
+
+	if out.Tag == "complete" {
+		return out.Metadata, nil
+	}
+
+	status, err := c.waitForJob(func() (jobStatus, error) {
+		return c.CheckShareJobStatus(&CheckShareJobStatusInput{
+			AsyncJobID: out.AsyncJobID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return status.(*CheckShareJobStatusOutput).Metadata, nil
+}
+
+// AddMember specifies a member to add and their access level.
+type AddMember struct {
+	Member      *MemberSelector `json:"member"`
+	AccessLevel AccessType      `json:"access_level,omitempty"`
+}
+
+// AddFolderMemberInput request input.
+type AddFolderMemberInput struct {
+	SharedFolderID string       `json:"shared_folder_id"`
+	Members        []*AddMember `json:"members"`
+	Quiet          bool         `json:"quiet"`
+	CustomMessage  string       `json:"custom_message,omitempty"`
+}
+
+// AddFolderMember invites members to a shared folder.
+func (c *Sharing) AddFolderMember(in *AddFolderMemberInput) (err error) {
+	body, err := c.call("/sharing/add_folder_member", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	return
+}
+
+// RemoveFolderMemberInput request input.
+type RemoveFolderMemberInput struct {
+	SharedFolderID string          `json:"shared_folder_id"`
+	Member         *MemberSelector `json:"member"`
+	LeaveACopy     bool            `json:"leave_a_copy"`
+}
+
+// RemoveFolderMember removes a member from a shared folder asynchronously,
+// see CheckRemoveMemberJobStatus and RemoveFolderMemberAndWait.
+func (c *Sharing) RemoveFolderMember(in *RemoveFolderMemberInput) (out *AsyncJobOutput, err error) {
+	body, err := c.call("/sharing/remove_folder_member", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// CheckRemoveMemberJobStatus checks the status of a RemoveFolderMember job.
+func (c *Sharing) CheckRemoveMemberJobStatus(in *CheckJobStatusInput) (out *JobStatusOutput, err error) {
+	body, err := c.call("/sharing/check_remove_member_job_status", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// RemoveFolderMemberAndWait removes a member from a shared folder,
+// waiting for the job to complete.
+func (c *Sharing) RemoveFolderMemberAndWait(in *RemoveFolderMemberInput) error {
+	out, err := c.RemoveFolderMember(in)
+	if err != nil {
+		return err
+	}
+
+	if out.Tag == "complete" {
+		return nil
+	}
+
+	_, err = c.waitForJob(func() (jobStatus, error) {
+		return c.CheckRemoveMemberJobStatus(&CheckJobStatusInput{AsyncJobID: out.AsyncJobID})
+	})
+
+	return err
+}
+
+// UpdateFolderMemberInput request input.
+type UpdateFolderMemberInput struct {
+	SharedFolderID string          `json:"shared_folder_id"`
+	Member         *MemberSelector `json:"member"`
+	AccessLevel    AccessType      `json:"access_level"`
+}
+
+// MemberAccessLevelResult is the access level granted to a member.
+type MemberAccessLevelResult struct {
+	AccessLevel struct {
+		Tag AccessType `json:".tag"`
+	} `json:"access_level"`
+	Warning string `json:"warning,omitempty"`
+}
+
+// UpdateFolderMember changes a member's access level to a shared folder.
+func (c *Sharing) UpdateFolderMember(in *UpdateFolderMemberInput) (out *MemberAccessLevelResult, err error) {
+	body, err := c.call("/sharing/update_folder_member", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// MemberAction defines actions that may be taken on members.
+type MemberAction string
+
+// Member actions supported.
+const (
+	MemberActionLeaveACopy          MemberAction = "leave_a_copy"
+	MemberActionMakeEditor          MemberAction = "make_editor"
+	MemberActionMakeOwner           MemberAction = "make_owner"
+	MemberActionMakeViewer          MemberAction = "make_viewer"
+	MemberActionMakeViewerNoComment MemberAction = "make_viewer_no_comment"
+	MemberActionRemove              MemberAction = "remove"
+)
+
+// MemberPermission determines whether an action may be taken on a member.
+type MemberPermission struct {
+	Action struct {
+		Tag MemberAction `json:".tag"`
+	} `json:"action"`
+	Allow  bool `json:"allow"`
+	Reason *struct {
+		Tag string `json:".tag"`
+	} `json:"reason,omitempty"`
+}
+
+// UserInfo describes a user who is a member of shared content.
+type UserInfo struct {
+	AccountID    string `json:"account_id"`
+	Email        string `json:"email"`
+	DisplayName  string `json:"display_name"`
+	SameTeam     bool   `json:"same_team"`
+	TeamMemberID string `json:"team_member_id,omitempty"`
+}
+
+// GroupInfo describes a group which is a member of shared content.
+type GroupInfo struct {
+	GroupName           string `json:"group_name"`
+	GroupID             string `json:"group_id"`
+	GroupManagementType struct {
+		Tag string `json:".tag"`
+	} `json:"group_management_type"`
+	GroupType struct {
+		Tag string `json:".tag"`
+	} `json:"group_type"`
+	IsMember    bool   `json:"is_member"`
+	IsOwner     bool   `json:"is_owner"`
+	SameTeam    bool   `json:"same_team"`
+	MemberCount uint64 `json:"member_count,omitempty"`
+}
+
+// UserMembershipInfo describes a user's membership of shared content.
+type UserMembershipInfo struct {
+	AccessType struct {
+		Tag AccessType `json:".tag"`
+	} `json:"access_type"`
+	User        UserInfo            `json:"user"`
+	Permissions []*MemberPermission `json:"permissions,omitempty"`
+	Initials    string              `json:"initials,omitempty"`
+	IsInherited bool                `json:"is_inherited"`
+}
+
+// GroupMembershipInfo describes a group's membership of shared content.
+type GroupMembershipInfo struct {
+	AccessType struct {
+		Tag AccessType `json:".tag"`
+	} `json:"access_type"`
+	Group       GroupInfo           `json:"group"`
+	Permissions []*MemberPermission `json:"permissions,omitempty"`
+	Initials    string              `json:"initials,omitempty"`
+	IsInherited bool                `json:"is_inherited"`
+}
+
+// InviteeMembershipInfo describes an invitation to shared content.
+type InviteeMembershipInfo struct {
+	AccessType struct {
+		Tag AccessType `json:".tag"`
+	} `json:"access_type"`
+	Invitee struct {
+		Tag   string `json:".tag"`
+		Email string `json:"email"`
+	} `json:"invitee"`
+	Permissions []*MemberPermission `json:"permissions,omitempty"`
+	Initials    string              `json:"initials,omitempty"`
+	IsInherited bool                `json:"is_inherited"`
+	User        *UserInfo           `json:"user,omitempty"`
+}
+
+// ListFolderMembersInput request input.
+type ListFolderMembersInput struct {
+	SharedFolderID string         `json:"shared_folder_id"`
+	Actions        []MemberAction `json:"actions,omitempty"`
+	Limit          uint64         `json:"limit,omitempty"`
+}
+
+// ListFolderMembersOutput request output.
+type ListFolderMembersOutput struct {
+	Users    []*UserMembershipInfo    `json:"users"`
+	Groups   []*GroupMembershipInfo   `json:"groups"`
+	Invitees []*InviteeMembershipInfo `json:"invitees"`
+	Cursor   string                   `json:"cursor"`
+}
+
+// ListFolderMembers returns the members of a shared folder.
+func (c *Sharing) ListFolderMembers(in *ListFolderMembersInput) (out *ListFolderMembersOutput, err error) {
+	body, err := c.call("/sharing/list_folder_members", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// ListFolderMembersContinueInput request input.
+type ListFolderMembersContinueInput struct {
+	Cursor string `json:"cursor"`
+}
+
+// ListFolderMembersContinue paginates using the cursor from ListFolderMembers.
+func (c *Sharing) ListFolderMembersContinue(in *ListFolderMembersContinueInput) (out *ListFolderMembersOutput, err error) {
+	body, err := c.call("/sharing/list_folder_members/continue", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// UnshareFolderInput request input.
+type UnshareFolderInput struct {
+	SharedFolderID string `json:"shared_folder_id"`
+	LeaveACopy     bool   `json:"leave_a_copy"`
+}
+
+// UnshareFolder stops sharing a folder, which may complete asynchronously,
+// see CheckJobStatus and WaitForJob.
+func (c *Sharing) UnshareFolder(in *UnshareFolderInput) (out *AsyncJobOutput, err error) {
+	body, err := c.call("/sharing/unshare_folder", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// FileAction defines actions that may be taken on shared files.
+type FileAction string
+
+// File actions supported.
+const (
+	FileActionDisableViewerInfo     FileAction = "disable_viewer_info"
+	FileActionEditContents          FileAction = "edit_contents"
+	FileActionEnableViewerInfo      FileAction = "enable_viewer_info"
+	FileActionInviteViewer          FileAction = "invite_viewer"
+	FileActionInviteViewerNoComment FileAction = "invite_viewer_no_comment"
+	FileActionInviteEditor          FileAction = "invite_editor"
+	FileActionUnshare               FileAction = "unshare"
+	FileActionRelinquishMembership  FileAction = "relinquish_membership"
+	FileActionShareLink             FileAction = "share_link"
+	FileActionCreateLink            FileAction = "create_link"
+	FileActionCreateViewLink        FileAction = "create_view_link"
+	FileActionCreateEditLink        FileAction = "create_edit_link"
+)
+
+// AddFileMemberInput request input.
+type AddFileMemberInput struct {
+	File                string            `json:"file"`
+	Members             []*MemberSelector `json:"members"`
+	CustomMessage       string            `json:"custom_message,omitempty"`
+	Quiet               bool              `json:"quiet"`
+	AccessLevel         AccessType        `json:"access_level,omitempty"`
+	AddMessageAsComment bool              `json:"add_message_as_comment"`
+}
+
+// FileMemberActionResult is the result of adding a member to a file.
+// Err is set if the member could not be added.
+type FileMemberActionResult struct {
+	Member      *MemberSelector
+	AccessLevel AccessType
+	Err         *Error
+}
+
+// UnmarshalJSON implementation.
+func (r *FileMemberActionResult) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Member *MemberSelector `json:"member"`
+		Result json.RawMessage `json:"result"`
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	var result struct {
+		Tag     string `json:".tag"`
+		Success *struct {
+			Tag AccessType `json:".tag"`
+		} `json:"success"`
+	}
+
+	if err := json.Unmarshal(v.Result, &result); err != nil {
+		return err
+	}
+
+	r.Member = v.Member
+
+	if result.Success != nil {
+		r.AccessLevel = result.Success.Tag
+	}
+
+	if result.Tag != "success" {
+		r.Err = unionError(v.Result)
+	}
+
+	return nil
+}
+
+// AddFileMember shares a file with members, returning a result for each
+// member in order.
+func (c *Sharing) AddFileMember(in *AddFileMemberInput) (out []*FileMemberActionResult, err error) {
+	body, err := c.call("/sharing/add_file_member", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// RemoveFileMemberInput request input.
+type RemoveFileMemberInput struct {
+	File   string          `json:"file"`
+	Member *MemberSelector `json:"member"`
+}
+
+// RemoveFileMemberOutput request output. Err is set if the member could
+// not be removed.
+type RemoveFileMemberOutput struct {
+	MemberAccessLevelResult
+	Err *Error
+}
+
+// UnmarshalJSON implementation.
+func (o *RemoveFileMemberOutput) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Tag string `json:".tag"`
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v.Tag != "success" {
+		o.Err = unionError(b)
+		return nil
+	}
+
+	return json.Unmarshal(b, &o.MemberAccessLevelResult)
+}
+
+// RemoveFileMember2 removes a member from a file.
+func (c *Sharing) RemoveFileMember2(in *RemoveFileMemberInput) (out *RemoveFileMemberOutput, err error) {
+	body, err := c.call("/sharing/remove_file_member_2", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// UpdateFileMemberInput request input.
+type UpdateFileMemberInput struct {
+	File        string          `json:"file"`
+	Member      *MemberSelector `json:"member"`
+	AccessLevel AccessType      `json:"access_level"`
+}
+
+// UpdateFileMember changes a member's access level to a file.
+func (c *Sharing) UpdateFileMember(in *UpdateFileMemberInput) (out *MemberAccessLevelResult, err error) {
+	body, err := c.call("/sharing/update_file_member", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// ListFileMembersInput request input.
+type ListFileMembersInput struct {
+	File             string         `json:"file"`
+	Actions          []MemberAction `json:"actions,omitempty"`
+	IncludeInherited *bool          `json:"include_inherited,omitempty"`
+	Limit            uint64         `json:"limit,omitempty"`
+}
+
+// ListFileMembersOutput request output.
+type ListFileMembersOutput struct {
+	Users    []*UserMembershipInfo    `json:"users"`
+	Groups   []*GroupMembershipInfo   `json:"groups"`
+	Invitees []*InviteeMembershipInfo `json:"invitees"`
+	Cursor   string                   `json:"cursor"`
+}
+
+// ListFileMembers returns the members of a file.
+func (c *Sharing) ListFileMembers(in *ListFileMembersInput) (out *ListFileMembersOutput, err error) {
+	body, err := c.call("/sharing/list_file_members", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// ListFileMembersContinueInput request input.
+type ListFileMembersContinueInput struct {
+	Cursor string `json:"cursor"`
+}
+
+// ListFileMembersContinue paginates using the cursor from ListFileMembers.
+func (c *Sharing) ListFileMembersContinue(in *ListFileMembersContinueInput) (out *ListFileMembersOutput, err error) {
+	body, err := c.call("/sharing/list_file_members/continue", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// ListFileMembersBatchInput request input.
+type ListFileMembersBatchInput struct {
+	Files []string `json:"files"`
+	Limit uint64   `json:"limit,omitempty"`
+}
+
+// ListFileMembersBatchResult is the members of a file from a batch.
+// Err is set if the file's members could not be listed.
+type ListFileMembersBatchResult struct {
+	File        string
+	Members     *ListFileMembersOutput
+	MemberCount uint64
+	Err         *Error
+}
+
+// UnmarshalJSON implementation.
+func (r *ListFileMembersBatchResult) UnmarshalJSON(b []byte) error {
+	var v struct {
+		File   string          `json:"file"`
+		Result json.RawMessage `json:"result"`
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	var result struct {
+		Tag         string                 `json:".tag"`
+		Members     *ListFileMembersOutput `json:"members"`
+		MemberCount uint64                 `json:"member_count"`
+	}
+
+	if err := json.Unmarshal(v.Result, &result); err != nil {
+		return err
+	}
+
+	r.File = v.File
+	r.Members = result.Members
+	r.MemberCount = result.MemberCount
+
+	if result.Tag != "result" {
+		r.Err = unionError(v.Result)
+	}
+
+	return nil
+}
+
+// ListFileMembersBatch returns the members of several files, with a
+// result for each file in order. Pagination is not supported, use
+// ListFileMembers for files with more members than Limit.
+func (c *Sharing) ListFileMembersBatch(in *ListFileMembersBatchInput) (out []*ListFileMembersBatchResult, err error) {
+	body, err := c.call("/sharing/list_file_members/batch", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// FilePermission determines whether an action may be taken on a file.
+type FilePermission struct {
+	Action struct {
+		Tag FileAction `json:".tag"`
+	} `json:"action"`
+	Allow  bool `json:"allow"`
+	Reason *struct {
+		Tag string `json:".tag"`
+	} `json:"reason,omitempty"`
+}
+
+// SharedFileMetadata includes basic information about a shared file.
+type SharedFileMetadata struct {
+	AccessType *struct {
+		Tag AccessType `json:".tag"`
+	} `json:"access_type,omitempty"`
+	ID                   string                     `json:"id"`
+	Name                 string                     `json:"name"`
+	Policy               FolderPolicy               `json:"policy"`
+	PreviewURL           string                     `json:"preview_url"`
+	PathDisplay          string                     `json:"path_display,omitempty"`
+	PathLower            string                     `json:"path_lower,omitempty"`
+	Permissions          []*FilePermission          `json:"permissions,omitempty"`
+	TimeInvited          time.Time                  `json:"time_invited,omitempty"`
+	OwnerDisplayNames    []string                   `json:"owner_display_names,omitempty"`
+	ParentSharedFolderID string                     `json:"parent_shared_folder_id,omitempty"`
+	LinkMetadata         *SharedContentLinkMetadata `json:"link_metadata,omitempty"`
+}
+
+// ListReceivedFilesInput request input.
+type ListReceivedFilesInput struct {
+	Limit   uint64       `json:"limit,omitempty"`
+	Actions []FileAction `json:"actions,omitempty"`
+}
+
+// ListReceivedFilesOutput request output.
+type ListReceivedFilesOutput struct {
+	Entries []*SharedFileMetadata `json:"entries"`
+	Cursor  string                `json:"cursor"`
+}
+
+// ListReceivedFiles returns the files the current user has been invited to.
+func (c *Sharing) ListReceivedFiles(in *ListReceivedFilesInput) (out *ListReceivedFilesOutput, err error) {
+	body, err := c.call("/sharing/list_received_files", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// ListReceivedFilesContinueInput request input.
+type ListReceivedFilesContinueInput struct {
+	Cursor string `json:"cursor"`
+}
+
+// ListReceivedFilesContinue paginates using the cursor from ListReceivedFiles.
+func (c *Sharing) ListReceivedFilesContinue(in *ListReceivedFilesContinueInput) (out *ListReceivedFilesOutput, err error) {
+	body, err := c.call("/sharing/list_received_files/continue", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// GetFolderMetadataInput request input.
+type GetFolderMetadataInput struct {
+	SharedFolderID string         `json:"shared_folder_id"`
+	Actions        []FolderAction `json:"actions,omitempty"`
+}
+
+// GetFolderMetadata returns the metadata of a shared folder.
+func (c *Sharing) GetFolderMetadata(in *GetFolderMetadataInput) (out *SharedFolderMetadata, err error) {
+	body, err := c.call("/sharing/get_folder_metadata", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// MountFolderInput request input.
+type MountFolderInput struct {
+	SharedFolderID string `json:"shared_folder_id"`
+}
+
+// MountFolder adds a shared folder the current user is a member of to
+// their Dropbox.
+func (c *Sharing) MountFolder(in *MountFolderInput) (out *SharedFolderMetadata, err error) {
+	body, err := c.call("/sharing/mount_folder", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// UnmountFolderInput request input.
+type UnmountFolderInput struct {
+	SharedFolderID string `json:"shared_folder_id"`
+}
+
+// UnmountFolder removes a shared folder from the current user's Dropbox,
+// keeping their membership so it may be mounted again.
+func (c *Sharing) UnmountFolder(in *UnmountFolderInput) (err error) {
+	body, err := c.call("/sharing/unmount_folder", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	return
+}
+
+// RelinquishFolderMembershipInput request input.
+type RelinquishFolderMembershipInput struct {
+	SharedFolderID string `json:"shared_folder_id"`
+	LeaveACopy     bool   `json:"leave_a_copy"`
+}
+
+// RelinquishFolderMembership removes the current user from a shared folder,
+// which may complete asynchronously, see CheckJobStatus and WaitForJob.
+func (c *Sharing) RelinquishFolderMembership(in *RelinquishFolderMembershipInput) (out *AsyncJobOutput, err error) {
+	body, err := c.call("/sharing/relinquish_folder_membership", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// TransferFolderInput request input.
+type TransferFolderInput struct {
+	SharedFolderID string `json:"shared_folder_id"`
+	ToDropboxID    string `json:"to_dropbox_id"`
+}
+
+// TransferFolder transfers ownership of a shared folder to a member.
+func (c *Sharing) TransferFolder(in *TransferFolderInput) (err error) {
+	body, err := c.call("/sharing/transfer_folder", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	return
+}
+
+// UpdateFolderPolicyInput request input, zero values leave the policy unchanged.
+type UpdateFolderPolicyInput struct {
+	SharedFolderID   string           `json:"shared_folder_id"`
+	MemberPolicy     MemberPolicy     `json:"member_policy,omitempty"`
+	ACLUpdatePolicy  ACLUpdatePolicy  `json:"acl_update_policy,omitempty"`
+	SharedLinkPolicy SharedLinkPolicy `json:"shared_link_policy,omitempty"`
+	Actions          []FolderAction   `json:"actions,omitempty"`
+}
+
+// UpdateFolderPolicy changes the policies of a shared folder.
+func (c *Sharing) UpdateFolderPolicy(in *UpdateFolderPolicyInput) (out *SharedFolderMetadata, err error) {
+	body, err := c.call("/sharing/update_folder_policy", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
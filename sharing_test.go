@@ -1,7 +1,11 @@
 package dropbox
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -16,6 +20,112 @@ func TestSharing_CreateSharedLink(t *testing.T) {
 	assert.Equal(t, "/hello.txt", out.Path)
 }
 
+func TestSharing_SharedLinkSettings(t *testing.T) {
+	c := client()
+
+	_, err := c.Files.Upload(&UploadInput{
+		Path:   "/settings.txt",
+		Mode:   WriteModeOverwrite,
+		Reader: strings.NewReader("hello"),
+	})
+	assert.NoError(t, err, "uploading")
+
+	expires := time.Now().Add(24 * time.Hour)
+
+	out, err := c.Sharing.CreateSharedLink(&CreateSharedLinkInput{
+		Path: "/settings.txt",
+		Settings: &SharedLinkSettings{
+			Audience: LinkAudiencePublic,
+			Access:   LinkAccessLevelViewer,
+			Expires:  expires,
+		},
+	})
+	assert.NoError(t, err, "creating link")
+	assert.Equal(t, "file", out.Tag)
+	assert.Equal(t, "/settings.txt", out.PathLower)
+	assert.WithinDuration(t, expires, out.Expires, time.Second)
+
+	link, err := c.Sharing.ModifySharedLinkSettings(&ModifySharedLinkSettingsInput{
+		URL:              out.URL,
+		RemoveExpiration: true,
+	})
+	assert.NoError(t, err, "modifying link")
+	assert.True(t, link.Expires.IsZero(), "expiry should be removed")
+}
+
+func TestSharing_GetSharedLinkFile(t *testing.T) {
+	c := client()
+
+	_, err := c.Files.Upload(&UploadInput{
+		Path:   "/linked/hello.txt",
+		Mode:   WriteModeOverwrite,
+		Reader: strings.NewReader("hello"),
+	})
+	assert.NoError(t, err, "uploading")
+
+	link, err := c.Sharing.CreateSharedLink(&CreateSharedLinkInput{
+		Path: "/linked",
+	})
+	assert.NoError(t, err, "creating link")
+
+	m, err := c.Sharing.GetSharedLinkMetadata(&GetSharedLinkMetadataInput{
+		URL:  link.URL,
+		Path: "/hello.txt",
+	})
+	assert.NoError(t, err, "getting metadata")
+	assert.Equal(t, "file", m.Tag)
+	assert.Equal(t, "hello.txt", m.Name)
+
+	out, err := c.Sharing.GetSharedLinkFile(&GetSharedLinkFileInput{
+		URL:  link.URL,
+		Path: "/hello.txt",
+	})
+	assert.NoError(t, err, "getting file")
+	defer out.Body.Close()
+
+	b, err := ioutil.ReadAll(out.Body)
+	assert.NoError(t, err, "reading")
+	assert.Equal(t, "hello", string(b))
+	assert.Equal(t, uint64(5), out.Metadata.Size)
+
+	list, err := c.Files.ListFolder(&ListFolderInput{
+		Path:       "",
+		SharedLink: &SharedLink{URL: link.URL},
+	})
+	assert.NoError(t, err, "listing shared folder")
+	assert.Len(t, list.Entries, 1)
+}
+
+func TestSharing_RevokeSharedLinks(t *testing.T) {
+	c := client()
+
+	_, err := c.Files.Upload(&UploadInput{
+		Path:   "/revoke/hello.txt",
+		Mode:   WriteModeOverwrite,
+		Reader: strings.NewReader("hello"),
+	})
+	assert.NoError(t, err, "uploading")
+
+	out, err := c.Sharing.CreateSharedLink(&CreateSharedLinkInput{
+		Path: "/revoke/hello.txt",
+	})
+	assert.NoError(t, err, "creating link")
+
+	revoked, err := c.Sharing.RevokeSharedLinks(&RevokeSharedLinksInput{
+		Path: "/revoke",
+	})
+	assert.NoError(t, err, "revoking links")
+	assert.Len(t, revoked, 1)
+	assert.Equal(t, out.URL, revoked[0].URL)
+
+	links, err := c.Sharing.ListSharedLinks(&ListShareLinksInput{
+		Path:       "/revoke/hello.txt",
+		DirectOnly: true,
+	})
+	assert.NoError(t, err, "listing links")
+	assert.Empty(t, links.Links)
+}
+
 func TestSharing_ListSharedFolder(t *testing.T) {
 	c := client()
 	out, err := c.Sharing.ListSharedFolders(&ListSharedFolderInput{
@@ -34,3 +144,216 @@ func TestSharing_ListSharedFolder(t *testing.T) {
 		assert.NotEmpty(t, out.Entries, "output should be non-empty")
 	}
 }
+
+func TestSharing_ListSharedFolderPermissions(t *testing.T) {
+	c := client()
+	out, err := c.Sharing.ListSharedFolders(&ListSharedFolderInput{
+		Limit:   1,
+		Actions: []FolderAction{FolderActionInviteEditor, FolderActionUnshare},
+	})
+
+	assert.NoError(t, err, "listing shared folders")
+	assert.NotEmpty(t, out.Entries, "output should be non-empty")
+	assert.Len(t, out.Entries[0].Permissions, 2)
+	assert.Equal(t, FolderActionInviteEditor, out.Entries[0].Permissions[0].Action.Tag)
+}
+
+func TestSharing_ListMountableFolders(t *testing.T) {
+	c := client()
+	out, err := c.Sharing.ListMountableFolders(&ListSharedFolderInput{
+		Limit: 1,
+	})
+	assert.NoError(t, err, "listing mountable folders")
+
+	for out.Cursor != "" {
+		out, err = c.Sharing.ListMountableFoldersContinue(&ListSharedFolderContinueInput{
+			Cursor: out.Cursor,
+		})
+		assert.NoError(t, err, "listing mountable folders")
+	}
+}
+
+func TestSharing_FolderMembers(t *testing.T) {
+	c := client()
+
+	_, err := c.Files.CreateFolder(&CreateFolderInput{Path: "/shared"})
+	assert.NoError(t, err, "creating folder")
+
+	folder, err := c.Sharing.ShareFolderAndWait(&ShareFolderInput{
+		Path:         "/shared",
+		MemberPolicy: MemberPolicyAnyone,
+		ForceAsync:   true,
+	})
+	assert.NoError(t, err, "sharing folder")
+	assert.Equal(t, "/shared", folder.PathLower)
+
+	member := MemberEmail("go-dropbox@example.com")
+
+	err = c.Sharing.AddFolderMember(&AddFolderMemberInput{
+		SharedFolderID: folder.SharedFolderID,
+		Members:        []*AddMember{{Member: member, AccessLevel: Viewer}},
+		Quiet:          true,
+	})
+	assert.NoError(t, err, "adding member")
+
+	_, err = c.Sharing.UpdateFolderMember(&UpdateFolderMemberInput{
+		SharedFolderID: folder.SharedFolderID,
+		Member:         member,
+		AccessLevel:    Editor,
+	})
+	assert.NoError(t, err, "updating member")
+
+	members, err := c.Sharing.ListFolderMembers(&ListFolderMembersInput{
+		SharedFolderID: folder.SharedFolderID,
+		Actions:        []MemberAction{MemberActionRemove},
+	})
+	assert.NoError(t, err, "listing members")
+	assert.NotEmpty(t, members.Users, "should include the owner")
+	assert.NotEmpty(t, members.Invitees, "should include the invitee")
+
+	err = c.Sharing.RemoveFolderMemberAndWait(&RemoveFolderMemberInput{
+		SharedFolderID: folder.SharedFolderID,
+		Member:         member,
+	})
+	assert.NoError(t, err, "removing member")
+
+	job, err := c.Sharing.UnshareFolder(&UnshareFolderInput{
+		SharedFolderID: folder.SharedFolderID,
+	})
+	assert.NoError(t, err, "unsharing folder")
+	assert.NoError(t, c.Sharing.WaitForJob(job), "waiting for unshare")
+}
+
+func TestSharing_FileMembers(t *testing.T) {
+	c := client()
+
+	up, err := c.Files.Upload(&UploadInput{
+		Path:   "/contract.txt",
+		Mode:   WriteModeOverwrite,
+		Reader: strings.NewReader("contract"),
+	})
+	assert.NoError(t, err, "uploading")
+
+	member := MemberEmail("go-dropbox@example.com")
+
+	results, err := c.Sharing.AddFileMember(&AddFileMemberInput{
+		File:        up.ID,
+		Members:     []*MemberSelector{member},
+		Quiet:       true,
+		AccessLevel: Viewer,
+	})
+	assert.NoError(t, err, "adding member")
+	assert.Len(t, results, 1)
+	assert.Nil(t, results[0].Err)
+
+	_, err = c.Sharing.UpdateFileMember(&UpdateFileMemberInput{
+		File:        up.ID,
+		Member:      member,
+		AccessLevel: Editor,
+	})
+	assert.NoError(t, err, "updating member")
+
+	members, err := c.Sharing.ListFileMembers(&ListFileMembersInput{
+		File: up.ID,
+	})
+	assert.NoError(t, err, "listing members")
+	assert.NotEmpty(t, members.Invitees, "should include the invitee")
+
+	batch, err := c.Sharing.ListFileMembersBatch(&ListFileMembersBatchInput{
+		Files: []string{up.ID, "/missing.txt"},
+	})
+	assert.NoError(t, err, "listing members in batch")
+	assert.Len(t, batch, 2)
+	assert.Nil(t, batch[0].Err)
+	assert.NotNil(t, batch[1].Err)
+
+	removed, err := c.Sharing.RemoveFileMember2(&RemoveFileMemberInput{
+		File:   up.ID,
+		Member: member,
+	})
+	assert.NoError(t, err, "removing member")
+	assert.Nil(t, removed.Err)
+
+	_, err = c.Sharing.ListReceivedFiles(&ListReceivedFilesInput{
+		Actions: []FileAction{FileActionInviteViewer},
+	})
+	assert.NoError(t, err, "listing received files")
+}
+
+func TestListReceivedFilesOutput_linkMetadata(t *testing.T) {
+	var out ListReceivedFilesOutput
+
+	err := json.Unmarshal([]byte(`{
+		"entries": [{
+			"id": "id:a4ayc_80_OEAAAAAAAAAXw",
+			"name": "contract.txt",
+			"policy": {"acl_update_policy": {".tag": "owner"}},
+			"preview_url": "https://www.dropbox.com/scl/fi/abc/contract.txt",
+			"link_metadata": {
+				"url": "https://www.dropbox.com/scl/fi/abc/contract.txt",
+				"audience_options": [{".tag": "public"}, {".tag": "no_one"}],
+				"current_audience": {".tag": "public"},
+				"link_permissions": [{"action": {".tag": "change_audience"}, "allow": true}],
+				"password_protected": false
+			}
+		}]
+	}`), &out)
+	assert.NoError(t, err)
+	assert.Len(t, out.Entries, 1)
+
+	link := out.Entries[0].LinkMetadata
+	assert.Equal(t, LinkAudiencePublic, link.CurrentAudience.Tag)
+	assert.Len(t, link.AudienceOptions, 2)
+	assert.Len(t, link.LinkPermissions, 1)
+	assert.Equal(t, "change_audience", link.LinkPermissions[0].Action.Tag)
+	assert.True(t, link.LinkPermissions[0].Allow)
+}
+
+func TestSharing_FolderPolicy(t *testing.T) {
+	c := client()
+
+	_, err := c.Files.CreateFolder(&CreateFolderInput{Path: "/policy"})
+	assert.NoError(t, err, "creating folder")
+
+	folder, err := c.Sharing.ShareFolderAndWait(&ShareFolderInput{
+		Path: "/policy",
+	})
+	assert.NoError(t, err, "sharing folder")
+
+	out, err := c.Sharing.UpdateFolderPolicy(&UpdateFolderPolicyInput{
+		SharedFolderID:  folder.SharedFolderID,
+		ACLUpdatePolicy: ACLUpdatePolicyOwner,
+	})
+	assert.NoError(t, err, "updating policy")
+	assert.Equal(t, ACLUpdatePolicyOwner, out.Policy.ACLUpdatePolicy.Tag)
+
+	m, err := c.Sharing.GetFolderMetadata(&GetFolderMetadataInput{
+		SharedFolderID: folder.SharedFolderID,
+	})
+	assert.NoError(t, err, "getting metadata")
+	assert.Equal(t, "/policy", m.PathLower)
+	assert.Equal(t, AccessInheritanceInherit, m.AccessInheritance.Tag)
+
+	err = c.Sharing.UnmountFolder(&UnmountFolderInput{
+		SharedFolderID: folder.SharedFolderID,
+	})
+	assert.NoError(t, err, "unmounting folder")
+
+	m, err = c.Sharing.GetFolderMetadata(&GetFolderMetadataInput{
+		SharedFolderID: folder.SharedFolderID,
+	})
+	assert.NoError(t, err, "getting metadata")
+	assert.Empty(t, m.PathLower, "should no longer be mounted")
+
+	m, err = c.Sharing.MountFolder(&MountFolderInput{
+		SharedFolderID: folder.SharedFolderID,
+	})
+	assert.NoError(t, err, "mounting folder")
+	assert.Equal(t, "/policy", m.PathLower)
+
+	job, err := c.Sharing.UnshareFolder(&UnshareFolderInput{
+		SharedFolderID: folder.SharedFolderID,
+	})
+	assert.NoError(t, err, "unsharing folder")
+	assert.NoError(t, c.Sharing.WaitForJob(job), "waiting for unshare")
+}
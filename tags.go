@@ -0,0 +1,135 @@
+package dropbox
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// tagsGetBatch is the number of paths requested per TagsGet call by FilterByTag.
+const tagsGetBatch = 20
+
+// FileTag is a tag on a file or folder.
+type FileTag struct {
+	Tag     string `json:".tag"`
+	TagText string `json:"tag_text"`
+}
+
+// TagsAddInput request input.
+type TagsAddInput struct {
+	Path    string `json:"path"`
+	TagText string `json:"tag_text"`
+}
+
+// TagsAdd adds a user generated tag to a file or folder.
+func (c *Files) TagsAdd(in *TagsAddInput) (err error) {
+	body, err := c.call("/files/tags/add", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	return
+}
+
+// TagsRemoveInput request input.
+type TagsRemoveInput struct {
+	Path    string `json:"path"`
+	TagText string `json:"tag_text"`
+}
+
+// TagsRemove removes a user generated tag from a file or folder.
+func (c *Files) TagsRemove(in *TagsRemoveInput) (err error) {
+	body, err := c.call("/files/tags/remove", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	return
+}
+
+// TagsGetInput request input.
+type TagsGetInput struct {
+	Paths []string `json:"paths"`
+}
+
+// PathToTags lists the tags of a path.
+type PathToTags struct {
+	Path string     `json:"path"`
+	Tags []*FileTag `json:"tags"`
+}
+
+// TagsGetOutput request output.
+type TagsGetOutput struct {
+	PathsToTags []*PathToTags `json:"paths_to_tags"`
+}
+
+// TagsGet returns the tags of files or folders.
+func (c *Files) TagsGet(in *TagsGetInput) (out *TagsGetOutput, err error) {
+	body, err := c.call("/files/tags/get", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// FilterByTag returns the entries tagged with tag, such as those from
+// ListFolder, fetching their tags in batches. Tags are compared
+// case-insensitively. Entries which are not files or folders, such as
+// deleted entries, are skipped.
+func (c *Files) FilterByTag(entries []*Metadata, tag string) (tagged []*Metadata, err error) {
+	var taggable []*Metadata
+	for _, m := range entries {
+		if m != nil && (m.Tag == "file" || m.Tag == "folder") {
+			taggable = append(taggable, m)
+		}
+	}
+
+	for len(taggable) > 0 {
+		n := tagsGetBatch
+		if n > len(taggable) {
+			n = len(taggable)
+		}
+
+		batch := taggable[:n]
+		taggable = taggable[n:]
+
+		var paths []string
+		for _, m := range batch {
+			paths = append(paths, m.PathLower)
+		}
+
+		out, err := c.TagsGet(&TagsGetInput{Paths: paths})
+		if err != nil {
+			return nil, err
+		}
+
+		matched := make(map[string]bool)
+		for _, p := range out.PathsToTags {
+			if hasTag(p.Tags, tag) {
+				matched[strings.ToLower(p.Path)] = true
+			}
+		}
+
+		for _, m := range batch {
+			if matched[m.PathLower] {
+				tagged = append(tagged, m)
+			}
+		}
+	}
+
+	return
+}
+
+// hasTag returns true if tags include tag.
+func hasTag(tags []*FileTag, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t.TagText, tag) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package dropbox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFiles_Tags(t *testing.T) {
+	c := client()
+
+	err := c.Files.TagsAdd(&TagsAddInput{
+		Path:    "/hello.txt",
+		TagText: "greeting",
+	})
+	assert.NoError(t, err)
+
+	out, err := c.Files.TagsGet(&TagsGetInput{
+		Paths: []string{"/hello.txt"},
+	})
+	assert.NoError(t, err)
+	assert.True(t, hasTag(out.PathsToTags[0].Tags, "greeting"))
+
+	list, err := c.Files.ListFolder(&ListFolderInput{Path: "/"})
+	assert.NoError(t, err)
+
+	entries := append(list.Entries, nil, &Metadata{Tag: "deleted", PathLower: "/deleted.txt"})
+
+	tagged, err := c.Files.FilterByTag(entries, "greeting")
+	assert.NoError(t, err)
+	assert.Len(t, tagged, 1)
+	assert.Equal(t, "/hello.txt", tagged[0].PathLower)
+
+	err = c.Files.TagsRemove(&TagsRemoveInput{
+		Path:    "/hello.txt",
+		TagText: "greeting",
+	})
+	assert.NoError(t, err)
+}
@@ -18,6 +18,27 @@ func NewUsers(config *Config) *Users {
 	}
 }
 
+// Name of a user.
+type Name struct {
+	GivenName       string `json:"given_name"`
+	Surname         string `json:"surname"`
+	FamiliarName    string `json:"familiar_name"`
+	DisplayName     string `json:"display_name"`
+	AbbreviatedName string `json:"abbreviated_name"`
+}
+
+// BasicAccount is the information about a user's account visible to others.
+type BasicAccount struct {
+	AccountID       string `json:"account_id"`
+	Name            Name   `json:"name"`
+	Email           string `json:"email"`
+	EmailVerified   bool   `json:"email_verified"`
+	Disabled        bool   `json:"disabled"`
+	IsTeammate      bool   `json:"is_teammate"`
+	ProfilePhotoURL string `json:"profile_photo_url,omitempty"`
+	TeamMemberID    string `json:"team_member_id,omitempty"`
+}
+
 // GetAccountInput request input.
 type GetAccountInput struct {
 	AccountID string `json:"account_id"`
@@ -25,13 +46,7 @@ type GetAccountInput struct {
 
 // GetAccountOutput request output.
 type GetAccountOutput struct {
-	AccountID string `json:"account_id"`
-	Name      struct {
-		GivenName    string `json:"given_name"`
-		Surname      string `json:"surname"`
-		FamiliarName string `json:"familiar_name"`
-		DisplayName  string `json:"display_name"`
-	} `json:"name"`
+	BasicAccount
 }
 
 // GetAccount returns information about a user's account.
@@ -46,23 +61,67 @@ func (c *Users) GetAccount(in *GetAccountInput) (out *GetAccountOutput, err erro
 	return
 }
 
+// GetAccountBatchInput request input.
+type GetAccountBatchInput struct {
+	AccountIDs []string `json:"account_ids"`
+}
+
+// GetAccountBatch returns information about several users' accounts, in
+// the order requested. The request fails if any account does not exist.
+func (c *Users) GetAccountBatch(in *GetAccountBatchInput) (out []*BasicAccount, err error) {
+	body, err := c.call("/users/get_account_batch", in)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	err = json.NewDecoder(body).Decode(&out)
+	return
+}
+
+// AccountType is the type of a user's account.
+type AccountType string
+
+// Account types supported.
+const (
+	AccountTypeBasic    AccountType = "basic"
+	AccountTypePro      AccountType = "pro"
+	AccountTypeBusiness AccountType = "business"
+)
+
+// RootInfo describes the root namespace of an account. Tag is "user", or
+// "team" for accounts whose root is a team space.
+type RootInfo struct {
+	Tag             string `json:".tag"`
+	RootNamespaceID string `json:"root_namespace_id"`
+	HomeNamespaceID string `json:"home_namespace_id"`
+	HomePath        string `json:"home_path,omitempty"`
+}
+
+// Team a user's account belongs to.
+type Team struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
 // GetCurrentAccountOutput request output.
 type GetCurrentAccountOutput struct {
-	AccountID string `json:"account_id"`
-	Name      struct {
-		GivenName    string `json:"given_name"`
-		Surname      string `json:"surname"`
-		FamiliarName string `json:"familiar_name"`
-		DisplayName  string `json:"display_name"`
-	} `json:"name"`
-	Email        string `json:"email"`
-	Locale       string `json:"locale"`
-	ReferralLink string `json:"referral_link"`
-	IsPaired     bool   `json:"is_paired"`
-	AccountType  struct {
-		Tag string `json:".tag"`
+	AccountID     string `json:"account_id"`
+	Name          Name   `json:"name"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Disabled      bool   `json:"disabled"`
+	Locale        string `json:"locale"`
+	ReferralLink  string `json:"referral_link"`
+	IsPaired      bool   `json:"is_paired"`
+	AccountType   struct {
+		Tag AccountType `json:".tag"`
 	} `json:"account_type"`
-	Country string `json:"country"`
+	RootInfo        RootInfo `json:"root_info"`
+	ProfilePhotoURL string   `json:"profile_photo_url,omitempty"`
+	Country         string   `json:"country"`
+	Team            *Team    `json:"team,omitempty"`
+	TeamMemberID    string   `json:"team_member_id,omitempty"`
 }
 
 // GetCurrentAccount returns information about the current user's account.
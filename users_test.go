@@ -11,3 +11,20 @@ func TestUsers_GetCurrentAccount(t *testing.T) {
 	_, err := c.Users.GetCurrentAccount()
 	assert.NoError(t, err)
 }
+
+func TestUsers_GetAccountBatch(t *testing.T) {
+	c := client()
+
+	me, err := c.Users.GetCurrentAccount()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, me.RootInfo.RootNamespaceID)
+	assert.NotEmpty(t, me.Locale)
+
+	out, err := c.Users.GetAccountBatch(&GetAccountBatchInput{
+		AccountIDs: []string{me.AccountID},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, out, 1)
+	assert.Equal(t, me.AccountID, out[0].AccountID)
+	assert.Equal(t, me.Name.DisplayName, out[0].Name.DisplayName)
+}
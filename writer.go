@@ -0,0 +1,193 @@
+package dropbox
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+)
+
+// DefaultChunkSize is the amount of data sent per request by Writer.
+const DefaultChunkSize = 8 * 1024 * 1024
+
+// MaxChunkSize is the largest amount of data accepted in a single
+// upload request.
+const MaxChunkSize = 150 * 1024 * 1024
+
+// errWriterClosed is returned when writing to a closed Writer.
+var errWriterClosed = errors.New("dropbox: write to closed writer")
+
+// WriterOptions for NewWriter.
+type WriterOptions struct {
+	Mode           WriteMode
+	AutoRename     bool
+	Mute           bool
+	ClientModified string
+
+	// ChunkSize is the amount of data buffered before it is sent,
+	// defaulting to DefaultChunkSize and limited to MaxChunkSize.
+	ChunkSize int
+}
+
+// Writer uploads the data written to it using an upload session, which is
+// committed when the Writer is closed.
+type Writer struct {
+	files   *Files
+	commit  CommitInfo
+	chunk   int
+	buf     []byte
+	cursor  UploadSessionCursor
+	started bool
+	closed  bool
+	err     error
+	hash    hash.Hash
+
+	// Metadata of the uploaded file, available after a successful Close.
+	Metadata *Metadata
+}
+
+// NewWriter returns a Writer uploading to path. Data is sent in chunks
+// as it is written, and the file is committed on Close. Files which fit
+// within a single chunk are sent with a single Upload request.
+func (c *Files) NewWriter(path string, opts *WriterOptions) *Writer {
+	if opts == nil {
+		opts = &WriterOptions{}
+	}
+
+	chunk := opts.ChunkSize
+	switch {
+	case chunk <= 0:
+		chunk = DefaultChunkSize
+	case chunk > MaxChunkSize:
+		chunk = MaxChunkSize
+	}
+
+	w := &Writer{
+		files: c,
+		chunk: chunk,
+		commit: CommitInfo{
+			Path:           path,
+			Mode:           opts.Mode,
+			AutoRename:     opts.AutoRename,
+			Mute:           opts.Mute,
+			ClientModified: opts.ClientModified,
+		},
+	}
+
+	if c.VerifyContentHash {
+		w.hash = NewContentHash()
+	}
+
+	return w
+}
+
+// Write implements io.Writer.
+func (w *Writer) Write(p []byte) (n int, err error) {
+	if w.closed {
+		return 0, errWriterClosed
+	}
+
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	for len(p) > 0 {
+		if len(w.buf) == w.chunk {
+			if err := w.flush(w.buf); err != nil {
+				w.err = err
+				return n, err
+			}
+			w.buf = w.buf[:0]
+		}
+
+		m := w.chunk - len(w.buf)
+		if m > len(p) {
+			m = len(p)
+		}
+
+		w.buf = append(w.buf, p[:m]...)
+
+		if w.hash != nil {
+			w.hash.Write(p[:m])
+		}
+
+		n += m
+		p = p[m:]
+	}
+
+	return n, nil
+}
+
+// Close commits the upload, returning any error from the commit. When
+// VerifyContentHash is enabled a ContentHashError is returned if the
+// committed file does not match the data written.
+func (w *Writer) Close() error {
+	if w.closed {
+		return w.err
+	}
+	w.closed = true
+
+	if w.err != nil {
+		return w.err
+	}
+
+	if !w.started {
+		out, err := w.files.Upload(&UploadInput{
+			Path:           w.commit.Path,
+			Mode:           w.commit.Mode,
+			AutoRename:     w.commit.AutoRename,
+			Mute:           w.commit.Mute,
+			ClientModified: w.commit.ClientModified,
+			Reader:         bytes.NewReader(w.buf),
+		})
+		if err != nil {
+			w.err = err
+			return err
+		}
+		w.buf = nil
+		w.Metadata = &out.Metadata
+		return nil
+	}
+
+	out, err := w.files.UploadSessionFinish(&UploadSessionFinishInput{
+		Cursor: w.cursor,
+		Commit: w.commit,
+		Reader: bytes.NewReader(w.buf),
+	})
+	if err != nil {
+		w.err = err
+		return err
+	}
+	w.buf = nil
+	w.Metadata = &out.Metadata
+
+	if w.hash != nil {
+		w.err = verifyContentHash(out.PathDisplay, out.ContentHash, w.hash)
+	}
+
+	return w.err
+}
+
+// flush sends a chunk, starting the session if necessary.
+func (w *Writer) flush(p []byte) error {
+	if !w.started {
+		out, err := w.files.UploadSessionStart(&UploadSessionStartInput{
+			Reader: bytes.NewReader(p),
+		})
+		if err != nil {
+			return err
+		}
+		w.started = true
+		w.cursor = UploadSessionCursor{SessionID: out.SessionID}
+	} else {
+		err := w.files.UploadSessionAppend(&UploadSessionAppendInput{
+			Cursor: w.cursor,
+			Reader: bytes.NewReader(p),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	w.cursor.Offset += uint64(len(p))
+	return nil
+}
@@ -0,0 +1,72 @@
+package dropbox
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFiles_NewWriter(t *testing.T) {
+	c := client()
+
+	data := bytes.Repeat([]byte("hello world\n"), 1000)
+
+	w := c.Files.NewWriter("/writer.txt", &WriterOptions{
+		Mode:      WriteModeOverwrite,
+		Mute:      true,
+		ChunkSize: 4096,
+	})
+
+	_, err := io.Copy(w, bytes.NewReader(data))
+	assert.NoError(t, err, "error writing")
+	assert.NoError(t, w.Close(), "error committing")
+	assert.Equal(t, "/writer.txt", w.Metadata.PathLower)
+	assert.Equal(t, uint64(len(data)), w.Metadata.Size)
+
+	out, err := c.Files.Download(&DownloadInput{"/writer.txt"})
+	assert.NoError(t, err, "error downloading")
+	defer out.Body.Close()
+
+	remote, err := ioutil.ReadAll(out.Body)
+	assert.NoError(t, err, "error reading remote")
+	assert.Equal(t, data, remote)
+}
+
+func TestFiles_NewWriter_chunkSize(t *testing.T) {
+	c := client()
+
+	w := c.Files.NewWriter("/writer.txt", &WriterOptions{ChunkSize: 1 << 30})
+	assert.Equal(t, MaxChunkSize, w.chunk)
+
+	w = c.Files.NewWriter("/writer.txt", nil)
+	assert.Equal(t, DefaultChunkSize, w.chunk)
+}
+
+// roundTripFunc is an http.RoundTripper calling itself.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implementation.
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestWriter_Write_flushError(t *testing.T) {
+	c := New(&Config{
+		HTTPClient: &http.Client{
+			Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				return nil, errors.New("boom")
+			}),
+		},
+	})
+
+	w := c.Files.NewWriter("/writer.txt", &WriterOptions{ChunkSize: 4})
+
+	n, err := w.Write([]byte("hello world"))
+	assert.Error(t, err)
+	assert.Equal(t, 4, n, "should accept the first chunk only")
+}
@@ -0,0 +1,118 @@
+package dropbox
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// DownloadZipTo writes a zip file of the folder at path to w. Folders
+// exceeding the limits of DownloadZip are zipped with WriteZip instead.
+func (c *Files) DownloadZipTo(path string, w io.Writer) error {
+	out, err := c.DownloadZip(&DownloadZipInput{Path: path})
+
+	if e, ok := err.(*Error); ok && (strings.HasPrefix(e.Summary, "too_large") || strings.HasPrefix(e.Summary, "too_many_files")) {
+		return c.WriteZip(path, w)
+	}
+
+	if err != nil {
+		return err
+	}
+	defer out.Body.Close()
+
+	_, err = io.Copy(w, out.Body)
+	return err
+}
+
+// WriteZip writes a zip file of the folder at path to w, listing and
+// downloading each file. Like DownloadZip the entries are nested
+// in a directory named after the folder.
+func (c *Files) WriteZip(path string, w io.Writer) error {
+	path = normalizePath(path)
+
+	// entries are matched against the lower-cased path reported by
+	// Dropbox, as path may be an id or revision
+	name := "Dropbox"
+	root := ""
+	if path != "" {
+		m, err := c.GetMetadata(&GetMetadataInput{Path: path})
+		if err != nil {
+			return err
+		}
+		name = m.Name
+		root = strings.TrimSuffix(m.PathLower, "/")
+	}
+
+	entries, err := c.listFolderAll(&ListFolderInput{
+		Path:      path,
+		Recursive: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].PathLower < entries[j].PathLower
+	})
+
+	z := zip.NewWriter(w)
+	prefix := root + "/"
+	listed, matched := 0, 0
+
+	for _, m := range entries {
+		if m.PathLower == root {
+			continue
+		}
+
+		listed++
+		if !strings.HasPrefix(m.PathLower, prefix) {
+			continue
+		}
+		matched++
+
+		rel := m.PathLower[len(prefix):]
+		if len(m.PathDisplay) == len(m.PathLower) {
+			rel = m.PathDisplay[len(prefix):]
+		}
+
+		switch m.Tag {
+		case "folder":
+			if _, err := z.Create(name + "/" + rel + "/"); err != nil {
+				return err
+			}
+		case "file":
+			if err := c.writeZipFile(z, name+"/"+rel, m); err != nil {
+				return err
+			}
+		}
+	}
+
+	if listed > 0 && matched == 0 {
+		return fmt.Errorf("dropbox: none of the %d entries listed are within %s", listed, prefix)
+	}
+
+	return z.Close()
+}
+
+// writeZipFile downloads a file into the zip.
+func (c *Files) writeZipFile(z *zip.Writer, name string, m *Metadata) error {
+	f, err := z.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: m.ClientModified,
+	})
+	if err != nil {
+		return err
+	}
+
+	out, err := c.Download(&DownloadInput{Path: m.ID})
+	if err != nil {
+		return err
+	}
+	defer out.Body.Close()
+
+	_, err = io.Copy(f, out.Body)
+	return err
+}
@@ -0,0 +1,67 @@
+package dropbox
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func uploadZipFixtures(t *testing.T, c *Client) {
+	for _, path := range []string{"/zip/a.txt", "/zip/nested/b.txt"} {
+		_, err := c.Files.Upload(&UploadInput{
+			Mute:   true,
+			Mode:   WriteModeOverwrite,
+			Path:   path,
+			Reader: bytes.NewBufferString(path),
+		})
+		assert.NoError(t, err, "error uploading file")
+	}
+}
+
+func zipNames(t *testing.T, b []byte) (names []string) {
+	r, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	assert.NoError(t, err, "error reading zip")
+
+	for _, f := range r.File {
+		if !f.FileInfo().IsDir() {
+			names = append(names, f.Name)
+		}
+	}
+	return
+}
+
+func TestFiles_DownloadZip(t *testing.T) {
+	c := client()
+	uploadZipFixtures(t, c)
+
+	out, err := c.Files.DownloadZip(&DownloadZipInput{"/zip"})
+	assert.NoError(t, err)
+	defer out.Body.Close()
+
+	assert.Equal(t, "/zip", out.Metadata.PathLower)
+
+	b, err := ioutil.ReadAll(out.Body)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"zip/a.txt", "zip/nested/b.txt"}, zipNames(t, b))
+}
+
+func TestFiles_WriteZip(t *testing.T) {
+	c := client()
+	uploadZipFixtures(t, c)
+
+	var buf bytes.Buffer
+	assert.NoError(t, c.Files.WriteZip("/zip", &buf))
+	assert.ElementsMatch(t, []string{"zip/a.txt", "zip/nested/b.txt"}, zipNames(t, buf.Bytes()))
+
+	m, err := c.Files.GetMetadata(&GetMetadataInput{Path: "/zip"})
+	assert.NoError(t, err)
+
+	for _, path := range []string{m.ID, "/zip/"} {
+		buf.Reset()
+		assert.NoError(t, c.Files.WriteZip(path, &buf), path)
+		assert.ElementsMatch(t, []string{"zip/a.txt", "zip/nested/b.txt"}, zipNames(t, buf.Bytes()), path)
+	}
+}